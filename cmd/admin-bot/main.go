@@ -8,11 +8,17 @@ import (
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/config"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/notify"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/scheduler"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/waitlist"
 	sqlc "github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/admin"
+	clientSqlc "github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/fsm"
 	hikeHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/handler"
+	hikeNotifier "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/notifier"
 	hikeRepository "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/repository"
 	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
 
@@ -20,6 +26,7 @@ import (
 	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/user/service"
 
 	bookingHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/handler"
+	bookingNotifier "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/notifier"
 	bookingRepository "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/repository"
 	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
 )
@@ -45,30 +52,59 @@ func main() {
 	}
 	bot.Debug = false
 
+	// Client bot is used to message clients, who never talk to the admin bot
+	clientBot, err := tgbot.NewBotAPI(cfg.ClientBotToken)
+	if err != nil {
+		log.Fatal(err)
+	}
+	clientBot.Debug = false
+
 	// Init DB
-	conn, err := pgx.Connect(ctx, cfg.DatabaseURL)
+	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer conn.Close(ctx)
+	defer pool.Close()
 
 	// Init SQLC
-	queries := sqlc.New(conn)
+	queries := sqlc.New(pool)
 
 	// Init application dependencies
-	// --- Hike --- /
-	hikeRep := hikeRepository.New(queries)
-	hikeSvc := hikeService.New(hikeRep)
-	hikeHnd := hikeHandler.New(bot, hikeSvc, cfg.StorageRoot, loc)
-
 	// --- User --- /
 	userRepo := userRepository.New(queries)
 	userSvc := userService.New(userRepo)
 
+	clientNotify := notify.New(clientBot, loc)
+
+	// Waitlist promotion is shared with the client bot and runs on its queries
+	waitlistSvc := waitlist.New(pool, clientSqlc.New(pool), clientBot, cfg.AdminChatID, clientNotify, loc)
+
 	// --- Booking --- /
-	bookingRepo := bookingRepository.New(queries)
-	bookingSvc := bookingService.New(bookingRepo)
-	bookingHnd := bookingHandler.New(bot, userSvc, bookingSvc)
+	bookingRepo := bookingRepository.New(pool, queries)
+	bookingNtf := bookingNotifier.New(clientBot, cfg.AdminChatID, clientNotify)
+	bookingSvc := bookingService.New(bookingRepo, bookingNtf, waitlistSvc)
+	bookingHnd := bookingHandler.New(bot, userSvc, bookingSvc, loc)
+
+	// --- Hike --- /
+	hikeFSM := fsm.NewFSM(fsm.NewPostgresStorage(queries), fsm.DefaultTTL, log)
+	go hikeFSM.RunCleanup(ctx, time.Hour)
+
+	hikeRep := hikeRepository.New(pool, queries)
+	hikeNtf := hikeNotifier.New(clientNotify)
+	hikeSvc := hikeService.New(hikeRep, hikeNtf, waitlistSvc)
+	hikeHnd := hikeHandler.New(bot, hikeFSM, hikeSvc, bookingSvc, cfg.StorageRoot, loc)
+
+	// Background jobs
+	sched := scheduler.New(loc, log)
+	sched.Every("scheduled hike publishing", time.Minute, hikeSvc.PublishDue)
+	sched.Every("booking completion", 10*time.Minute, bookingSvc.CompleteFinished)
+	sched.Every("untaken booking escalation", 10*time.Minute, func(ctx context.Context, now time.Time) error {
+		return bookingSvc.EscalateUntaken(ctx, now.Add(-cfg.BookingEscalateAfter))
+	})
+	sched.Every("untaken booking expiry", 10*time.Minute, func(ctx context.Context, now time.Time) error {
+		return bookingSvc.ExpireUntaken(ctx, now.Add(-cfg.BookingExpireAfter))
+	})
+	go sched.Run(ctx)
 
 	// Init router
 	r := adminbot.NewRouter(bot, cfg.AdminChatID, hikeHnd, bookingHnd)
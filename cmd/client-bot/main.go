@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/config"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/notify"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/scheduler"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/waitlist"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot"
 	sqlc "github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
@@ -14,6 +18,7 @@ import (
 	hikeRepository "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/repository"
 	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
 
+	userHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/handler"
 	userRepository "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/repository"
 	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
 
@@ -21,8 +26,17 @@ import (
 	adminService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/admin/service"
 
 	bookingHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/handler"
+	bookingNotifier "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/notifier"
 	bookingRepository "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/repository"
 	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
+
+	reminderNotifier "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/reminder/notifier"
+	reminderRepository "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/reminder/repository"
+	reminderService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/reminder/service"
+
+	reviewHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/handler"
+	reviewRepository "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/repository"
+	reviewService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/service"
 )
 
 func main() {
@@ -35,6 +49,12 @@ func main() {
 	// Get Config
 	cfg := config.MustLoadClientBot()
 
+	// Init Location (Timezone)
+	loc, err := time.LoadLocation(cfg.Timezone)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	// Init TelegramBotAPI
 	bot, err := tgbot.NewBotAPI(cfg.ClientBotToken)
 	if err != nil {
@@ -42,6 +62,13 @@ func main() {
 	}
 	bot.Debug = false
 
+	// Admin bot is used to message managers privately
+	adminBot, err := tgbot.NewBotAPI(cfg.AdminBotToken)
+	if err != nil {
+		log.Fatal(err)
+	}
+	adminBot.Debug = false
+
 	// Init DB
 	pool, err := pgxpool.New(ctx, cfg.DatabaseURL)
 	if err != nil {
@@ -53,26 +80,45 @@ func main() {
 	queries := sqlc.New(pool)
 
 	// Init Application Dependencies
-	// --- Hike --- /
-	hikeRep := hikeRepository.New(queries)
-	hikeSrv := hikeService.New(hikeRep)
-	hikeHnd := hikeHandler.New(bot, cfg, hikeSrv)
-
 	// --- User --- /
 	userRepo := userRepository.New(queries)
 	userSrv := userService.New(userRepo)
+	userHnd := userHandler.New(bot, userSrv)
+
+	// --- Review --- /
+	reviewRepo := reviewRepository.New(queries)
+	reviewSrv := reviewService.New(reviewRepo)
+	reviewHnd := reviewHandler.New(bot, userSrv, reviewSrv)
+
+	// --- Hike --- /
+	hikeRep := hikeRepository.New(queries)
+	hikeSrv := hikeService.New(hikeRep)
+	hikeHnd := hikeHandler.New(bot, cfg, hikeSrv, reviewSrv, userSrv, loc)
 
 	// --- Admin --- /
 	adminRepo := adminRepository.New(queries)
 	adminSrv := adminService.New(adminRepo)
 
 	// --- Booking --- /
-	bookRepo := bookingRepository.New(queries)
-	bookSrv := bookingService.New(bookRepo)
+	bookRepo := bookingRepository.New(pool, queries)
+	notifySrv := notify.New(bot, loc)
+	bookNtf := bookingNotifier.New(bot, adminBot, cfg, notifySrv)
+	waitlistSrv := waitlist.New(pool, queries, bot, cfg.AdminChatID, notifySrv, loc)
+	bookSrv := bookingService.New(bookRepo, bookNtf, waitlistSrv)
 	bookHnd := bookingHandler.New(bot, cfg, userSrv, adminSrv, hikeSrv, bookSrv)
 
+	// --- Reminder --- /
+	reminderRepo := reminderRepository.New(queries)
+	reminderNtf := reminderNotifier.New(notifySrv)
+	reminderSrv := reminderService.New(reminderRepo, reminderNtf)
+
+	// Background jobs
+	sched := scheduler.New(loc, log)
+	sched.Every("hike reminders", 5*time.Minute, reminderSrv.SendDue)
+	go sched.Run(ctx)
+
 	// Init Router
-	r := clientbot.NewRouter(bot, cfg, hikeHnd, bookHnd)
+	r := clientbot.NewRouter(bot, cfg, hikeHnd, bookHnd, reviewHnd, userHnd, userSrv)
 
 	// Bot updates
 	u := tgbot.NewUpdate(0)
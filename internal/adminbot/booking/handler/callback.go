@@ -2,9 +2,12 @@ package booking
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/booking"
@@ -20,6 +23,15 @@ func (h *BookingHandler) HandleCallback(ctx context.Context, q *tgbot.CallbackQu
 	}
 
 	switch {
+	case strings.HasPrefix(q.Data, "booking:stats:"):
+		return h.SwitchStatsPeriod(ctx, q)
+
+	case strings.HasPrefix(q.Data, "booking:pay_verify:"):
+		return h.ReviewPayment(ctx, q, bookingService.PaymentVerified)
+
+	case strings.HasPrefix(q.Data, "booking:pay_reject:"):
+		return h.ReviewPayment(ctx, q, bookingService.PaymentRejected)
+
 	case strings.HasPrefix(q.Data, "booking:confirm:"):
 		return h.AskConfirmAction(ctx, q, "confirm")
 
@@ -92,6 +104,7 @@ func (h *BookingHandler) ApplyAction(ctx context.Context, q *tgbot.CallbackQuery
 		TgUserID:   q.From.ID,
 		TgUsername: q.From.UserName,
 		FullName:   strings.TrimSpace(q.From.FirstName + " " + q.From.LastName),
+		Lang:       i18n.Match(q.From.LanguageCode),
 	})
 	if err != nil {
 		return err
@@ -114,12 +127,18 @@ func (h *BookingHandler) ApplyAction(ctx context.Context, q *tgbot.CallbackQuery
 		return h.answerCallback(q.ID, "Неизвестное действие.")
 	}
 
+	// A failed client notification or waitlist promotion doesn't undo the
+	// status change, so the card is still updated and the error is only reported.
 	updatedBooking, err := h.bookingService.UpdateStatus(ctx, bookingID, adminID, newStatus)
+	var followUpErr error
+	if errors.Is(err, bookingService.ErrClientNotification) || errors.Is(err, bookingService.ErrWaitlistPromotion) {
+		followUpErr, err = err, nil
+	}
 	if err != nil {
-		switch err {
-		case bookingService.ErrNotYourBooking:
+		switch {
+		case errors.Is(err, bookingService.ErrNotYourBooking):
 			return h.answerCallback(q.ID, "Это не ваша заявка.")
-		case bookingService.ErrInvalidStatusTransition:
+		case errors.Is(err, bookingService.ErrInvalidStatusTransition):
 			return h.answerCallback(q.ID, "Недопустимая смена статуса.")
 		default:
 			return h.answerCallback(q.ID, "Не удалось изменить статус заявки.")
@@ -136,7 +155,11 @@ func (h *BookingHandler) ApplyAction(ctx context.Context, q *tgbot.CallbackQuery
 		return logger.WrapError(err)
 	}
 
-	return h.answerCallback(q.ID, successText)
+	if err := h.answerCallback(q.ID, successText); err != nil {
+		return err
+	}
+
+	return followUpErr
 }
 
 func (h *BookingHandler) RestoreActions(ctx context.Context, q *tgbot.CallbackQuery) error {
@@ -167,6 +190,102 @@ func (h *BookingHandler) RestoreActions(ctx context.Context, q *tgbot.CallbackQu
 	return h.answerCallback(q.ID, "Действие отменено.")
 }
 
+// ReviewPayment handles the buttons under a receipt forwarded from the client bot.
+func (h *BookingHandler) ReviewPayment(ctx context.Context, q *tgbot.CallbackQuery, status bookingService.PaymentStatus) error {
+	if q == nil || q.Message == nil || q.From == nil {
+		return nil
+	}
+
+	// booking:pay_verify:15, the ID is the payment one
+	_, paymentID, ok := parseBookingAction(q.Data)
+	if !ok {
+		return h.answerCallback(q.ID, "Не удалось обработать действие.")
+	}
+
+	adminID, err := h.userService.EnsureTelegramUser(ctx, userService.TelegramUser{
+		TgUserID:   q.From.ID,
+		TgUsername: q.From.UserName,
+		FullName:   strings.TrimSpace(q.From.FirstName + " " + q.From.LastName),
+		Lang:       i18n.Match(q.From.LanguageCode),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The review is saved even if the client couldn't be notified
+	_, err = h.bookingService.ReviewPayment(ctx, paymentID, adminID, status)
+	var notifyErr error
+	if errors.Is(err, bookingService.ErrPaymentNotification) {
+		notifyErr, err = err, nil
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, bookingService.ErrNotYourBooking):
+			return h.answerCallback(q.ID, "Это не ваша заявка.")
+		case errors.Is(err, bookingService.ErrPaymentAlreadyReviewed):
+			return h.answerCallback(q.ID, "Этот чек уже проверен.")
+		default:
+			_ = h.answerCallback(q.ID, "Не удалось сохранить проверку оплаты.")
+			return err
+		}
+	}
+
+	edit := tgbot.NewEditMessageCaption(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		bookingUI.PaymentReviewedCaption(q.Message.Caption, status),
+	)
+	edit.ParseMode = "HTML"
+
+	if _, err := h.bot.Request(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	successText := "Оплата подтверждена."
+	if status == bookingService.PaymentRejected {
+		successText = "Чек отклонён, клиент получит уведомление."
+	}
+
+	if err := h.answerCallback(q.ID, successText); err != nil {
+		return err
+	}
+
+	return notifyErr
+}
+
+func (h *BookingHandler) SwitchStatsPeriod(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.Message == nil {
+		return nil
+	}
+
+	period := bookingService.StatsPeriod(strings.TrimPrefix(q.Data, "booking:stats:"))
+	switch period {
+	case bookingService.PeriodWeek, bookingService.PeriodMonth, bookingService.PeriodAll:
+	default:
+		return h.answerCallback(q.ID, "Неизвестный период.")
+	}
+
+	stats, err := h.bookingService.Stats(ctx, period, time.Now().In(h.loc))
+	if err != nil {
+		_ = h.answerCallback(q.ID, "Не удалось загрузить статистику.")
+		return err
+	}
+
+	edit := tgbot.NewEditMessageTextAndMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		bookingUI.StatsMessage(stats),
+		bookingUI.StatsPeriodKeyboard(period),
+	)
+	edit.ParseMode = "HTML"
+
+	if _, err := h.bot.Send(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.answerCallback(q.ID, "")
+}
+
 func (h *BookingHandler) answerCallback(callbackID, text string) error {
 	cb := tgbot.NewCallback(callbackID, text)
 	_, err := h.bot.Request(cb)
@@ -1,6 +1,8 @@
 package booking
 
 import (
+	"time"
+
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
@@ -11,12 +13,14 @@ type BookingHandler struct {
 	bot            *tgbot.BotAPI
 	userService    userService.Service
 	bookingService bookingService.Service
+	loc            *time.Location
 }
 
-func New(b *tgbot.BotAPI, uS userService.Service, bS bookingService.Service) *BookingHandler {
+func New(b *tgbot.BotAPI, uS userService.Service, bS bookingService.Service, loc *time.Location) *BookingHandler {
 	return &BookingHandler{
 		bot:            b,
 		userService:    uS,
 		bookingService: bS,
+		loc:            loc,
 	}
 }
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
 	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/booking"
 	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/user/service"
 )
@@ -33,6 +36,7 @@ func (h *BookingHandler) ListBookings(ctx context.Context, m *tgbot.Message) err
 		TgUserID:   tgUserID,
 		TgUsername: tgUsername,
 		FullName:   fullName,
+		Lang:       i18n.Match(m.From.LanguageCode),
 	})
 	if err != nil {
 		return err
@@ -66,3 +70,25 @@ func (h *BookingHandler) ListBookings(ctx context.Context, m *tgbot.Message) err
 
 	return nil
 }
+
+func (h *BookingHandler) Stats(ctx context.Context, m *tgbot.Message) error {
+	if m == nil {
+		return nil
+	}
+
+	period := bookingService.PeriodWeek
+
+	stats, err := h.bookingService.Stats(ctx, period, time.Now().In(h.loc))
+	if err != nil {
+		msg := tgbot.NewMessage(m.Chat.ID, "Не удалось загрузить статистику.")
+		_, _ = h.bot.Send(msg)
+		return err
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, bookingUI.StatsMessage(stats))
+	msg.ParseMode = "HTML"
+	msg.ReplyMarkup = bookingUI.StatsPeriodKeyboard(period)
+
+	_, err = h.bot.Send(msg)
+	return logger.WrapError(err)
+}
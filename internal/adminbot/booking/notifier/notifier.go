@@ -0,0 +1,135 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/notify"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
+	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/booking"
+)
+
+// notifier sends messages through the client bot: clients have only
+// started a chat with it, and the admin chat handles its booking_take buttons.
+type notifier struct {
+	clientBot   *tgbot.BotAPI
+	adminChatID int64
+	notify      notify.Service
+}
+
+func New(clientBot *tgbot.BotAPI, adminChatID int64, n notify.Service) service.Notifier {
+	return &notifier{
+		clientBot:   clientBot,
+		adminChatID: adminChatID,
+		notify:      n,
+	}
+}
+
+var statusTemplates = map[service.BookingStatus]notify.Template{
+	service.StatusConfirmed: notify.BookingConfirmed,
+	service.StatusCanceled:  notify.BookingCanceled,
+	service.StatusCompleted: notify.BookingCompleted,
+}
+
+func (n *notifier) NotifyStatusChanged(ctx context.Context, booking service.Booking) error {
+	tpl, ok := statusTemplates[booking.Status]
+	if !ok {
+		return nil
+	}
+
+	data := notify.Booking{
+		ID:              booking.ID,
+		HikeTitle:       booking.HikeTitleIn(booking.UserLang),
+		HikeStartsAt:    booking.HikeStartsAt,
+		ManagerName:     booking.AdminName,
+		ManagerUsername: booking.AdminUsername,
+	}
+
+	// A completed hike is the moment to ask for a rating
+	if booking.Status == service.StatusCompleted {
+		return n.notify.SendWithKeyboard(ctx, booking.UserTgID, booking.UserLang, tpl, data, notify.RatingKeyboard(booking.ID))
+	}
+
+	return n.notify.Send(ctx, booking.UserTgID, booking.UserLang, tpl, data)
+}
+
+func (n *notifier) NotifyPaymentReviewed(ctx context.Context, payment service.Payment) error {
+	msg := tgbot.NewMessage(payment.UserTgID, bookingUI.ClientPaymentReviewedMessage(payment))
+	msg.ParseMode = tgbot.ModeHTML
+
+	if _, err := n.clientBot.Send(msg); err != nil {
+		return logger.WrapError(fmt.Errorf("failed to notify client tg_id=%d: %w", payment.UserTgID, err))
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyHikeCanceled(ctx context.Context, booking service.Booking) error {
+	var errs []error
+
+	err := n.notify.Send(ctx, booking.UserTgID, booking.UserLang, notify.HikeCanceled, notify.Booking{
+		ID:              booking.ID,
+		HikeTitle:       booking.HikeTitleIn(booking.UserLang),
+		HikeStartsAt:    booking.HikeStartsAt,
+		ManagerName:     booking.AdminName,
+		ManagerUsername: booking.AdminUsername,
+		Reason:          booking.CancelReason,
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	// The booking message was posted to the admin chat by the client bot
+	if booking.AdminMessageID != 0 {
+		edit := tgbot.NewEditMessageText(n.adminChatID, booking.AdminMessageID, bookingUI.AdminHikeCanceledMessage(booking))
+		edit.ParseMode = tgbot.ModeHTML
+
+		if _, err := n.clientBot.Request(edit); err != nil {
+			errs = append(errs, logger.WrapError(fmt.Errorf("failed to edit admin message id=%d: %w", booking.AdminMessageID, err)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *notifier) NotifyExpired(ctx context.Context, booking service.Booking) error {
+	var errs []error
+
+	err := n.notify.Send(ctx, booking.UserTgID, booking.UserLang, notify.BookingExpired, notify.Booking{
+		ID:           booking.ID,
+		HikeTitle:    booking.HikeTitleIn(booking.UserLang),
+		HikeStartsAt: booking.HikeStartsAt,
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if booking.AdminMessageID != 0 {
+		edit := tgbot.NewEditMessageText(n.adminChatID, booking.AdminMessageID, bookingUI.AdminBookingExpiredMessage(booking))
+		edit.ParseMode = tgbot.ModeHTML
+
+		if _, err := n.clientBot.Request(edit); err != nil {
+			errs = append(errs, logger.WrapError(fmt.Errorf("failed to edit admin message id=%d: %w", booking.AdminMessageID, err)))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (n *notifier) NotifyUntaken(ctx context.Context, booking service.Booking) error {
+	msg := tgbot.NewMessage(n.adminChatID, bookingUI.AdminBookingEscalationMessage(booking))
+	msg.ParseMode = tgbot.ModeHTML
+	// The reply brings up the booking message with its take button
+	msg.ReplyToMessageID = booking.AdminMessageID
+	msg.AllowSendingWithoutReply = true
+
+	if _, err := n.clientBot.Send(msg); err != nil {
+		return logger.WrapError(fmt.Errorf("failed to send admin message to chat=%v: %w", n.adminChatID, err))
+	}
+
+	return nil
+}
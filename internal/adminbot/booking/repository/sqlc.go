@@ -2,20 +2,27 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/admin"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type repository struct {
+	db      txBeginner
 	queries *admin.Queries
 }
 
-func New(q *admin.Queries) service.Repository {
-	return &repository{queries: q}
+func New(db txBeginner, q *admin.Queries) service.Repository {
+	return &repository{db: db, queries: q}
 }
 
 func (r *repository) GetByID(ctx context.Context, id int32) (service.Booking, error) {
@@ -38,12 +45,16 @@ func (r *repository) GetByID(ctx context.Context, id int32) (service.Booking, er
 	}, nil
 }
 
-func (r *repository) UpdateStatus(ctx context.Context, id int32, newStatus service.BookingStatus) (service.Booking, error) {
+func (r *repository) UpdateStatus(ctx context.Context, id int32, from, to service.BookingStatus) (service.Booking, error) {
 	rawBooking, err := r.queries.UpdateBookingStatus(ctx, admin.UpdateBookingStatusParams{
-		ID:        id,
-		NewStatus: string(newStatus),
+		ID:             id,
+		NewStatus:      string(to),
+		ExpectedStatus: string(from),
 	})
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return service.Booking{}, logger.WrapError(service.ErrInvalidStatusTransition)
+		}
 		return service.Booking{}, logger.WrapError(err)
 	}
 
@@ -76,17 +87,269 @@ func (r *repository) ListAdminBookings(ctx context.Context, adminID int32) ([]se
 		}
 
 		bookings = append(bookings, service.Booking{
-			ID:        row.ID,
-			HikeID:    row.HikeID,
-			HikeTitle: row.HikeTitle,
-			UserID:    row.UserID,
-			UserName:  row.UserName,
-			UserTgID:  row.UserTgID,
-			Status:    service.BookingStatus(row.Status),
-			TakenAt:   takenAt,
-			CreatedAt: row.CreatedAt,
+			ID:            row.ID,
+			HikeID:        row.HikeID,
+			HikeTitle:     row.HikeTitle,
+			UserID:        row.UserID,
+			UserName:      row.UserName,
+			UserTgID:      row.UserTgID,
+			Status:        service.BookingStatus(row.Status),
+			TakenAt:       takenAt,
+			CreatedAt:     row.CreatedAt,
+			HikePriceGel:  row.HikePriceGel,
+			PaidAmount:    row.PaidAmount,
+			PaymentStatus: service.PaymentStatus(row.PaymentStatus),
+		})
+	}
+
+	return bookings, nil
+}
+
+func (r *repository) ListHikeRoster(ctx context.Context, hikeID int32) ([]service.Booking, error) {
+	rows, err := r.queries.ListHikeRoster(ctx, hikeID)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	bookings := make([]service.Booking, 0, len(rows))
+	for _, row := range rows {
+		bookings = append(bookings, service.Booking{
+			ID:            row.ID,
+			HikeID:        hikeID,
+			UserName:      row.UserName,
+			UserUsername:  row.UserUsername,
+			UserTgID:      row.UserTgID,
+			UserPhone:     row.UserPhone,
+			Status:        service.BookingStatus(row.Status),
+			HikePriceGel:  row.HikePriceGel,
+			PaidAmount:    row.PaidAmount,
+			PaymentStatus: service.PaymentStatus(row.PaymentStatus),
+		})
+	}
+
+	return bookings, nil
+}
+
+func (r *repository) CancelHike(ctx context.Context, hikeID int32, reason string) ([]int32, error) {
+	var ids []int32
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		q := r.queries.WithTx(tx)
+
+		err := q.SetPublished(ctx, admin.SetPublishedParams{
+			ID:          hikeID,
+			IsPublished: false,
+		})
+		if err != nil {
+			return err
+		}
+
+		ids, err = q.CancelHikeBookings(ctx, admin.CancelHikeBookingsParams{
+			HikeID:       hikeID,
+			CancelReason: pgtype.Text{String: reason, Valid: reason != ""},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	return ids, nil
+}
+
+func (r *repository) CompleteFinished(ctx context.Context, endedBefore time.Time) ([]int32, error) {
+	ids, err := r.queries.CompleteFinishedBookings(ctx, endedBefore)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+	return ids, nil
+}
+
+func (r *repository) ExpireUntaken(ctx context.Context, before time.Time, reason string) ([]service.Booking, error) {
+	rows, err := r.queries.ExpireUntakenBookings(ctx, admin.ExpireUntakenBookingsParams{
+		CancelReason: pgtype.Text{String: reason, Valid: reason != ""},
+		UpdatedAt:    before,
+	})
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	bookings := make([]service.Booking, 0, len(rows))
+	for _, row := range rows {
+		bookings = append(bookings, service.Booking{
+			ID:     row.ID,
+			HikeID: row.HikeID,
 		})
 	}
 
 	return bookings, nil
 }
+
+func (r *repository) MarkEscalated(ctx context.Context, before time.Time) ([]int32, error) {
+	ids, err := r.queries.MarkUntakenBookingsEscalated(ctx, before)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+	return ids, nil
+}
+
+func (r *repository) GetDetails(ctx context.Context, id int32) (service.Booking, error) {
+	row, err := r.queries.GetBookingDetails(ctx, id)
+	if err != nil {
+		return service.Booking{}, logger.WrapError(err)
+	}
+
+	var takenByAdminID *int32
+	if row.TakenByAdminID.Valid {
+		takenByAdminID = &row.TakenByAdminID.Int32
+	}
+
+	return service.Booking{
+		ID:             row.ID,
+		HikeID:         row.HikeID,
+		HikeTitle:      row.HikeTitle,
+		HikeTitleEn:    row.HikeTitleEn,
+		HikeStartsAt:   row.HikeStartsAt,
+		HikeEndsAt:     row.HikeEndsAt,
+		UserID:         row.UserID,
+		UserName:       row.UserName,
+		UserUsername:   row.UserUsername,
+		UserTgID:       row.UserTgID,
+		UserLang:       row.UserLang,
+		Status:         service.BookingStatus(row.Status),
+		TakenByAdminID: takenByAdminID,
+		AdminName:      row.AdminName,
+		AdminUsername:  row.AdminUsername,
+		AdminMessageID: int(row.AdminMessageID.Int64),
+		CancelReason:   row.CancelReason,
+		CreatedAt:      row.CreatedAt,
+	}, nil
+}
+
+func (r *repository) Stats(ctx context.Context, since time.Time) (service.Stats, error) {
+	byStatus, err := r.queries.CountBookingsByStatus(ctx, since)
+	if err != nil {
+		return service.Stats{}, logger.WrapError(err)
+	}
+
+	takeTime, err := r.queries.GetBookingTakeTimeStats(ctx, since)
+	if err != nil {
+		return service.Stats{}, logger.WrapError(err)
+	}
+
+	byHike, err := r.queries.ListBookingStatsByHike(ctx, since)
+	if err != nil {
+		return service.Stats{}, logger.WrapError(err)
+	}
+
+	byAdmin, err := r.queries.ListBookingStatsByAdmin(ctx, since)
+	if err != nil {
+		return service.Stats{}, logger.WrapError(err)
+	}
+
+	stats := service.Stats{
+		ByStatus:    make(map[service.BookingStatus]int64, len(byStatus)),
+		ByHike:      make([]service.HikeStats, 0, len(byHike)),
+		ByAdmin:     make([]service.AdminStats, 0, len(byAdmin)),
+		Taken:       takeTime.Taken,
+		AvgTakeTime: secondsToDuration(takeTime.AvgTakeSeconds),
+	}
+
+	for _, row := range byStatus {
+		stats.ByStatus[service.BookingStatus(row.Status)] = row.Count
+	}
+
+	for _, row := range byHike {
+		stats.ByHike = append(stats.ByHike, service.HikeStats{
+			HikeID:       row.HikeID,
+			HikeTitle:    row.HikeTitle,
+			HikeStartsAt: row.HikeStartsAt,
+			Total:        row.Total,
+			Confirmed:    row.Confirmed,
+			Completed:    row.Completed,
+			Canceled:     row.Canceled,
+		})
+	}
+
+	for _, row := range byAdmin {
+		stats.ByAdmin = append(stats.ByAdmin, service.AdminStats{
+			AdminID:       row.AdminID,
+			AdminName:     row.AdminName,
+			AdminUsername: row.AdminUsername,
+			Taken:         row.Taken,
+			Confirmed:     row.Confirmed,
+			Completed:     row.Completed,
+			Canceled:      row.Canceled,
+			AvgTakeTime:   secondsToDuration(row.AvgTakeSeconds),
+		})
+	}
+
+	return stats, nil
+}
+
+func (r *repository) HikeSummary(ctx context.Context, hikeID int32) (service.HikeSummary, error) {
+	byStatus, err := r.queries.CountHikeBookingsByStatus(ctx, hikeID)
+	if err != nil {
+		return service.HikeSummary{}, logger.WrapError(err)
+	}
+
+	revenue, err := r.queries.GetHikeRevenue(ctx, hikeID)
+	if err != nil {
+		return service.HikeSummary{}, logger.WrapError(err)
+	}
+
+	summary := service.HikeSummary{
+		ByStatus: make(map[service.BookingStatus]int64, len(byStatus)),
+		Revenue:  revenue,
+	}
+
+	for _, row := range byStatus {
+		summary.ByStatus[service.BookingStatus(row.Status)] = row.Count
+	}
+
+	return summary, nil
+}
+
+func (r *repository) GetPayment(ctx context.Context, id int32) (service.Payment, error) {
+	row, err := r.queries.GetPaymentDetails(ctx, id)
+	if err != nil {
+		return service.Payment{}, logger.WrapError(err)
+	}
+
+	var takenByAdminID *int32
+	if row.TakenByAdminID.Valid {
+		takenByAdminID = &row.TakenByAdminID.Int32
+	}
+
+	return service.Payment{
+		ID:             row.ID,
+		BookingID:      row.BookingID,
+		Amount:         row.Amount,
+		Currency:       row.Currency,
+		Status:         service.PaymentStatus(row.Status),
+		TakenByAdminID: takenByAdminID,
+		HikeTitle:      row.HikeTitle,
+		HikePriceGel:   row.HikePriceGel,
+		UserTgID:       row.UserTgID,
+	}, nil
+}
+
+func (r *repository) ReviewPayment(ctx context.Context, id, adminID int32, status service.PaymentStatus) error {
+	_, err := r.queries.ReviewPayment(ctx, admin.ReviewPaymentParams{
+		ID:                id,
+		Status:            string(status),
+		ReviewedByAdminID: pgtype.Int4{Int32: adminID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return logger.WrapError(service.ErrPaymentAlreadyReviewed)
+		}
+		return logger.WrapError(err)
+	}
+
+	return nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ExpiredReason is the cancel reason of bookings nobody took in time.
+const ExpiredReason = "Заявку не взяли в работу вовремя"
+
+// CompleteFinished completes confirmed bookings of hikes that ended before
+// now and asks the clients to rate the hike.
+func (s *service) CompleteFinished(ctx context.Context, now time.Time) error {
+	ids, err := s.repo.CompleteFinished(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if err := s.notifyStatusChanged(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("%w: booking id=%d: %w", ErrClientNotification, id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ExpireUntaken cancels new bookings nobody took since before, tells the
+// clients and gives the freed seats to the waitlist.
+func (s *service) ExpireUntaken(ctx context.Context, before time.Time) error {
+	expired, err := s.repo.ExpireUntaken(ctx, before, ExpiredReason)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, b := range expired {
+		if err := s.notifyExpired(ctx, b.ID); err != nil {
+			errs = append(errs, fmt.Errorf("%w: booking id=%d: %w", ErrClientNotification, b.ID, err))
+		}
+
+		if err := s.waitlist.Promote(ctx, b.HikeID); err != nil {
+			errs = append(errs, fmt.Errorf("%w: hike id=%d: %w", ErrWaitlistPromotion, b.HikeID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// EscalateUntaken reminds the admin chat, once per booking, about new
+// bookings nobody took since before.
+func (s *service) EscalateUntaken(ctx context.Context, before time.Time) error {
+	ids, err := s.repo.MarkEscalated(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		booking, err := s.repo.GetDetails(ctx, id)
+		if err == nil {
+			err = s.notifier.NotifyUntaken(ctx, booking)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%w: booking id=%d: %w", ErrAdminNotification, id, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *service) notifyExpired(ctx context.Context, bookingID int32) error {
+	booking, err := s.repo.GetDetails(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.NotifyExpired(ctx, booking)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type PaymentStatus string
+
+const (
+	PaymentSubmitted PaymentStatus = "submitted"
+	PaymentVerified  PaymentStatus = "verified"
+	PaymentRejected  PaymentStatus = "rejected"
+)
+
+var (
+	ErrPaymentAlreadyReviewed = errors.New("payment already reviewed")
+	ErrPaymentNotification    = errors.New("payment notification failed")
+)
+
+type Payment struct {
+	ID             int32
+	BookingID      int32
+	Amount         float64
+	Currency       string
+	Status         PaymentStatus
+	TakenByAdminID *int32
+	HikeTitle      string
+	HikePriceGel   int32
+	UserTgID       int64
+}
+
+// ReviewPayment verifies or rejects a submitted receipt. Only the manager
+// who took the booking may review it. The client is notified of the result,
+// a failed notification is returned wrapped in ErrPaymentNotification.
+func (s *service) ReviewPayment(ctx context.Context, paymentID, adminID int32, status PaymentStatus) (Payment, error) {
+	payment, err := s.repo.GetPayment(ctx, paymentID)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if payment.TakenByAdminID == nil || *payment.TakenByAdminID != adminID {
+		return Payment{}, ErrNotYourBooking
+	}
+
+	if payment.Status != PaymentSubmitted {
+		return Payment{}, ErrPaymentAlreadyReviewed
+	}
+
+	if err := s.repo.ReviewPayment(ctx, paymentID, adminID, status); err != nil {
+		return Payment{}, err
+	}
+	payment.Status = status
+
+	if err := s.notifier.NotifyPaymentReviewed(ctx, payment); err != nil {
+		return payment, fmt.Errorf("%w: %w", ErrPaymentNotification, err)
+	}
+
+	return payment, nil
+}
@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 )
 
 type BookingStatus string
@@ -14,44 +17,125 @@ const (
 	StatusConfirmed  BookingStatus = "confirmed"
 	StatusCompleted  BookingStatus = "completed"
 	StatusCanceled   BookingStatus = "canceled"
+	StatusWaitlisted BookingStatus = "waitlisted"
 )
 
 var (
 	ErrInvalidStatusTransition = errors.New("invalid status transition")
 	ErrNotYourBooking          = errors.New("not your booking")
+	ErrWaitlistPromotion       = errors.New("waitlist promotion failed")
+	ErrClientNotification      = errors.New("client notification failed")
+	ErrAdminNotification       = errors.New("admin notification failed")
 )
 
 type Booking struct {
 	ID             int32
 	HikeID         int32
 	HikeTitle      string
+	HikeTitleEn    string
+	HikeStartsAt   time.Time
+	HikeEndsAt     time.Time
 	UserID         int32
 	UserName       string
+	UserUsername   string
 	UserTgID       int64
+	UserLang       string
+	UserPhone      string
 	Status         BookingStatus
 	TakenByAdminID *int32
 	TakenAt        *time.Time
+	AdminName      string
+	AdminUsername  string
+	AdminMessageID int
+	CancelReason   string
 	CreatedAt      time.Time
+	HikePriceGel   int32
+	// PaidAmount sums verified payments, PaymentStatus is the status of
+	// the latest one, empty if the client hasn't sent a receipt yet.
+	PaidAmount    float64
+	PaymentStatus PaymentStatus
+}
+
+// HikeTitleIn returns the hike title in the language, falling back to Russian.
+func (b Booking) HikeTitleIn(lang string) string {
+	return i18n.Localized(lang, b.HikeTitle, b.HikeTitleEn)
 }
 
 type Repository interface {
 	GetByID(ctx context.Context, id int32) (Booking, error)
-	UpdateStatus(ctx context.Context, id int32, newStatus BookingStatus) (Booking, error)
+	GetDetails(ctx context.Context, id int32) (Booking, error)
+	// UpdateStatus moves the booking from one status to another. It returns
+	// ErrInvalidStatusTransition if the booking no longer has the from status.
+	UpdateStatus(ctx context.Context, id int32, from, to BookingStatus) (Booking, error)
 	ListAdminBookings(ctx context.Context, adminID int32) ([]Booking, error)
+	ListHikeRoster(ctx context.Context, hikeID int32) ([]Booking, error)
+	// CancelHike hides the hike and cancels its active bookings in one
+	// transaction, returning the IDs of the canceled bookings.
+	CancelHike(ctx context.Context, hikeID int32, reason string) ([]int32, error)
+	// CompleteFinished completes confirmed bookings of hikes that ended
+	// before the time and returns their IDs.
+	CompleteFinished(ctx context.Context, endedBefore time.Time) ([]int32, error)
+	// ExpireUntaken cancels new bookings not updated since before and
+	// returns them with the ID and HikeID set.
+	ExpireUntaken(ctx context.Context, before time.Time, reason string) ([]Booking, error)
+	// MarkEscalated returns the IDs of new bookings not updated since
+	// before that were not escalated yet, marking them escalated.
+	MarkEscalated(ctx context.Context, before time.Time) ([]int32, error)
+	Stats(ctx context.Context, since time.Time) (Stats, error)
+	HikeSummary(ctx context.Context, hikeID int32) (HikeSummary, error)
+	GetPayment(ctx context.Context, id int32) (Payment, error)
+	ReviewPayment(ctx context.Context, id, adminID int32, status PaymentStatus) error
+}
+
+type Notifier interface {
+	// NotifyStatusChanged tells the client their booking was confirmed, canceled or completed.
+	NotifyStatusChanged(ctx context.Context, booking Booking) error
+	NotifyPaymentReviewed(ctx context.Context, payment Payment) error
+	// NotifyHikeCanceled tells the client the hike was canceled and marks
+	// the booking message in the admin chat.
+	NotifyHikeCanceled(ctx context.Context, booking Booking) error
+	// NotifyExpired tells the client nobody took the booking in time and
+	// marks the booking message in the admin chat.
+	NotifyExpired(ctx context.Context, booking Booking) error
+	// NotifyUntaken reminds the admin chat about a booking nobody took.
+	NotifyUntaken(ctx context.Context, booking Booking) error
 }
 
 type Service interface {
 	GetByID(ctx context.Context, id int32) (Booking, error)
 	UpdateStatus(ctx context.Context, id, adminID int32, newStatus BookingStatus) (Booking, error)
 	ListAdminBookings(ctx context.Context, adminID int32) ([]Booking, error)
+	// ListHikeRoster returns all bookings of the hike, confirmed participants first.
+	ListHikeRoster(ctx context.Context, hikeID int32) ([]Booking, error)
+	// CancelHike returns the number of canceled bookings.
+	CancelHike(ctx context.Context, hikeID int32, reason string) (int, error)
+	// Stats aggregates bookings created in the current period, now sets the time zone.
+	Stats(ctx context.Context, period StatsPeriod, now time.Time) (Stats, error)
+	// HikeSummary counts all bookings of the hike and the verified payments.
+	HikeSummary(ctx context.Context, hikeID int32) (HikeSummary, error)
+	ReviewPayment(ctx context.Context, paymentID, adminID int32, status PaymentStatus) (Payment, error)
+
+	// Background jobs, see lifecycle.go. Failed notifications are returned
+	// wrapped in ErrClientNotification / ErrAdminNotification, the status
+	// changes stay.
+	CompleteFinished(ctx context.Context, now time.Time) error
+	ExpireUntaken(ctx context.Context, before time.Time) error
+	EscalateUntaken(ctx context.Context, before time.Time) error
+}
+
+// Waitlist gives seats freed by canceled bookings to waitlisted clients.
+type Waitlist interface {
+	Promote(ctx context.Context, hikeID int32) error
 }
 
 type service struct {
-	repo Repository
+	repo     Repository
+	notifier Notifier
+	waitlist Waitlist
 }
 
-func New(r Repository) Service {
-	return &service{repo: r}
+func New(r Repository, n Notifier, w Waitlist) Service {
+	return &service{repo: r, notifier: n, waitlist: w}
 }
 
 func (s *service) GetByID(ctx context.Context, id int32) (Booking, error) {
@@ -72,13 +156,73 @@ func (s *service) UpdateStatus(ctx context.Context, id, adminID int32, newStatus
 		return Booking{}, ErrInvalidStatusTransition
 	}
 
-	return s.repo.UpdateStatus(ctx, id, newStatus)
+	// The status is checked again by the update: the client may cancel or
+	// the booking may expire in the meantime
+	updated, err := s.repo.UpdateStatus(ctx, id, booking.Status, newStatus)
+	if err != nil {
+		return Booking{}, err
+	}
+
+	var errs []error
+
+	if err := s.notifyStatusChanged(ctx, id); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrClientNotification, err))
+	}
+
+	if newStatus == StatusCanceled {
+		if err := s.waitlist.Promote(ctx, updated.HikeID); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrWaitlistPromotion, err))
+		}
+	}
+
+	return updated, errors.Join(errs...)
 }
 
 func (s *service) ListAdminBookings(ctx context.Context, adminID int32) ([]Booking, error) {
 	return s.repo.ListAdminBookings(ctx, adminID)
 }
 
+func (s *service) ListHikeRoster(ctx context.Context, hikeID int32) ([]Booking, error) {
+	return s.repo.ListHikeRoster(ctx, hikeID)
+}
+
+// CancelHike unpublishes the hike and cancels all its active bookings with
+// the reason. Every affected client is notified, failed notifications are
+// returned wrapped in ErrClientNotification, the bookings stay canceled.
+func (s *service) CancelHike(ctx context.Context, hikeID int32, reason string) (int, error) {
+	ids, err := s.repo.CancelHike(ctx, hikeID, reason)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		if err := s.notifyHikeCanceled(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("%w: booking id=%d: %w", ErrClientNotification, id, err))
+		}
+	}
+
+	return len(ids), errors.Join(errs...)
+}
+
+func (s *service) notifyHikeCanceled(ctx context.Context, bookingID int32) error {
+	booking, err := s.repo.GetDetails(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.NotifyHikeCanceled(ctx, booking)
+}
+
+func (s *service) notifyStatusChanged(ctx context.Context, bookingID int32) error {
+	booking, err := s.repo.GetDetails(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.NotifyStatusChanged(ctx, booking)
+}
+
 func canTransition(from, to BookingStatus) bool {
 	switch from {
 
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+type StatsPeriod string
+
+const (
+	PeriodWeek  StatsPeriod = "week"
+	PeriodMonth StatsPeriod = "month"
+	PeriodAll   StatsPeriod = "all"
+)
+
+// Since returns the start of the current calendar period in now's location
+// (weeks start on Monday), or the zero time for PeriodAll.
+func (p StatsPeriod) Since(now time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case PeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return time.Time{}
+	}
+}
+
+type HikeStats struct {
+	HikeID       int32
+	HikeTitle    string
+	HikeStartsAt time.Time
+	Total        int64
+	Confirmed    int64
+	Completed    int64
+	Canceled     int64
+}
+
+type AdminStats struct {
+	AdminID       int32
+	AdminName     string
+	AdminUsername string
+	Taken         int64
+	Confirmed     int64
+	Completed     int64
+	Canceled      int64
+	AvgTakeTime   time.Duration
+}
+
+// Stats covers bookings created since Since. Confirmed counters include
+// completed bookings, as a booking is confirmed before it's completed.
+type Stats struct {
+	Period      StatsPeriod
+	Since       time.Time
+	ByStatus    map[BookingStatus]int64
+	ByHike      []HikeStats
+	ByAdmin     []AdminStats
+	Taken       int64
+	AvgTakeTime time.Duration
+}
+
+func (s Stats) Total() int64 {
+	var total int64
+	for _, n := range s.ByStatus {
+		total += n
+	}
+	return total
+}
+
+// Confirmed counts bookings that reached the confirmed status.
+func (s Stats) Confirmed() int64 {
+	return s.ByStatus[StatusConfirmed] + s.ByStatus[StatusCompleted]
+}
+
+// HikeSummary covers all bookings of one hike, Revenue sums the verified payments in GEL.
+type HikeSummary struct {
+	ByStatus map[BookingStatus]int64
+	Revenue  float64
+}
+
+func (s HikeSummary) Total() int64 {
+	var total int64
+	for _, n := range s.ByStatus {
+		total += n
+	}
+	return total
+}
+
+func (s *service) Stats(ctx context.Context, period StatsPeriod, now time.Time) (Stats, error) {
+	since := period.Since(now)
+
+	stats, err := s.repo.Stats(ctx, since)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats.Period = period
+	stats.Since = since
+	return stats, nil
+}
+
+func (s *service) HikeSummary(ctx context.Context, hikeID int32) (HikeSummary, error) {
+	return s.repo.HikeSummary(ctx, hikeID)
+}
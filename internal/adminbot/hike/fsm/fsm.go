@@ -1,6 +1,12 @@
 package fsm
 
-import "sync"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+)
 
 type State string
 
@@ -9,94 +15,248 @@ const (
 	StateCreateTitleRU   State = "create_title_ru"
 	StateCreateTitleEN   State = "create_title_en"
 	StateCreatePreviewRU State = "create_preview_ru"
+	StateCreatePreviewEN State = "create_preview_en"
 	StateCreateDescRU    State = "create_desc_ru"
 	StateCreateDescEN    State = "create_desc_en"
 
-	StateCreateDates State = "create_dates"
-	StateCreatePhoto State = "create_photo"
-	StateConfirm     State = "confirm"
+	StateCreateDates       State = "create_dates"
+	StateCreatePhoto       State = "create_photo"
+	StateCreatePublishAt   State = "create_publish_at"
+	StateCreateUnpublishAt State = "create_unpublish_at"
+	StateConfirm           State = "confirm"
 
-	StateCreatePrice         State = "create_price"
-	StateCreateDistanceKm    State = "create_distance_km"
-	StateCreateElevationGain State = "create_elevation_gain"
+	StateCreatePrice           State = "create_price"
+	StateCreateDistanceKm      State = "create_distance_km"
+	StateCreateElevationGain   State = "create_elevation_gain"
+	StateCreateDifficulty      State = "create_difficulty"
+	StateCreateTags            State = "create_tags"
+	StateCreateMaxParticipants State = "create_max_participants"
 
-	StateSelectHikeID       State = "select_hike_id"
 	StateSelectedHikeAction State = "selected_hike_action"
 	StateConfirmPublishHike State = "confirm_publish_hike"
 	StateConfirmHideHike    State = "confirm_hide_hike"
+	StateCancelHikeReason   State = "cancel_hike_reason"
+	StateConfirmCancelHike  State = "confirm_cancel_hike"
+
+	StateCloneDates       State = "clone_dates"
+	StateCloneRepeat      State = "clone_repeat"
+	StateCloneCount       State = "clone_count"
+	StateConfirmCloneHike State = "confirm_clone_hike"
+
+	StateEditHikeField   State = "edit_hike_field"
+	StateEditHikeValue   State = "edit_hike_value"
+	StateConfirmEditHike State = "confirm_edit_hike"
+
+	StateContinueDraft State = "continue_draft"
 )
 
-type session struct {
-	State State
-	Data  map[string]string
+// DefaultTTL is how long an untouched session or draft is kept.
+const DefaultTTL = 7 * 24 * time.Hour
+
+// IsCreate reports whether the state belongs to the hike creation scenario.
+func (s State) IsCreate() bool {
+	switch s {
+	case StateCreateTitleRU,
+		StateCreateTitleEN,
+		StateCreatePreviewRU,
+		StateCreatePreviewEN,
+		StateCreateDescRU,
+		StateCreateDescEN,
+		StateCreatePrice,
+		StateCreateDistanceKm,
+		StateCreateElevationGain,
+		StateCreateDifficulty,
+		StateCreateTags,
+		StateCreateMaxParticipants,
+		StateCreateDates,
+		StateCreatePhoto,
+		StateCreatePublishAt,
+		StateCreateUnpublishAt,
+		StateConfirm:
+		return true
+	default:
+		return false
+	}
 }
 
+// FSM keeps per-admin sessions in a Storage. Read errors are logged and
+// treated as an empty session, writes fail instead of overwriting a session
+// that could not be read.
 type FSM struct {
-	mu           sync.Mutex
-	userSessions map[int64]*session
+	mu      sync.Mutex
+	storage Storage
+	ttl     time.Duration
+	log     logger.Logger
 }
 
-func NewFSM() *FSM {
+func NewFSM(s Storage, ttl time.Duration, log logger.Logger) *FSM {
 	return &FSM{
-		userSessions: make(map[int64]*session),
+		storage: s,
+		ttl:     ttl,
+		log:     log,
 	}
 }
 
-func (f *FSM) State(userID int64) State {
+func (f *FSM) State(ctx context.Context, userID int64) State {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	if s, ok := f.userSessions[userID]; ok {
-		return s.State
+	s, ok, err := f.load(ctx, userID, SlotActive)
+	if err != nil {
+		f.log.StructuredError("fsm load error", err)
 	}
-	return StateIdle
+	if !ok {
+		return StateIdle
+	}
+	return s.State
 }
 
-func (f *FSM) Set(userID int64, st State) {
+// Set moves the session to the state and stores the values, nil values
+// keep the data as is.
+func (f *FSM) Set(ctx context.Context, userID int64, st State, values map[string]string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	s, ok := f.userSessions[userID]
-	if !ok {
-		s = &session{
-			Data: map[string]string{},
-		}
-		f.userSessions[userID] = s
+	s, _, err := f.load(ctx, userID, SlotActive)
+	if err != nil {
+		return err
 	}
 	s.State = st
+	for k, v := range values {
+		s.Data[k] = v
+	}
+	return f.storage.Save(ctx, userID, SlotActive, s)
 }
 
-func (f *FSM) Put(userID int64, k, v string) {
+// Put stores the values, keeping the state.
+func (f *FSM) Put(ctx context.Context, userID int64, values map[string]string) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	s, ok := f.userSessions[userID]
+	s, ok, err := f.load(ctx, userID, SlotActive)
+	if err != nil {
+		return err
+	}
 	if !ok {
-		s = &session{
-			Data: map[string]string{},
-		}
-		f.userSessions[userID] = s
+		s.State = StateIdle
 	}
-	s.Data[k] = v
+	for k, v := range values {
+		s.Data[k] = v
+	}
+	return f.storage.Save(ctx, userID, SlotActive, s)
 }
 
-func (f *FSM) Data(userID int64) map[string]string {
+func (f *FSM) Data(ctx context.Context, userID int64) map[string]string {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	s, ok := f.userSessions[userID]
-	if !ok {
-		return map[string]string{}
+	s, _, err := f.load(ctx, userID, SlotActive)
+	if err != nil {
+		f.log.StructuredError("fsm load error", err)
+	}
+	return s.Data
+}
+
+func (f *FSM) Reset(ctx context.Context, userID int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delete(ctx, userID, SlotActive)
+}
+
+// ParkDraft moves the current session to the draft slot, replacing an older draft.
+func (f *FSM) ParkDraft(ctx context.Context, userID int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok, err := f.load(ctx, userID, SlotActive)
+	if err != nil || !ok {
+		return err
 	}
-	cp := make(map[string]string, len(s.Data))
-	for k, v := range s.Data {
-		cp[k] = v
+	if err := f.storage.Save(ctx, userID, SlotDraft, s); err != nil {
+		return err
 	}
-	return cp
+	f.delete(ctx, userID, SlotActive)
+	return nil
 }
 
-func (f *FSM) Reset(userID int64) {
+func (f *FSM) Draft(ctx context.Context, userID int64) (Session, bool) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
-	delete(f.userSessions, userID)
+
+	s, ok, err := f.load(ctx, userID, SlotDraft)
+	if err != nil {
+		f.log.StructuredError("fsm load error", err)
+	}
+	return s, ok
+}
+
+// RestoreDraft makes the parked draft the current session and returns its state.
+func (f *FSM) RestoreDraft(ctx context.Context, userID int64) (State, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	s, ok, err := f.load(ctx, userID, SlotDraft)
+	if err != nil || !ok {
+		return StateIdle, false, err
+	}
+	if err := f.storage.Save(ctx, userID, SlotActive, s); err != nil {
+		return StateIdle, false, err
+	}
+	f.delete(ctx, userID, SlotDraft)
+	return s.State, true, nil
+}
+
+func (f *FSM) DropDraft(ctx context.Context, userID int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.delete(ctx, userID, SlotDraft)
+}
+
+// RunCleanup deletes expired sessions every interval until ctx is done.
+func (f *FSM) RunCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := f.storage.DeleteExpired(ctx, time.Now().Add(-f.ttl))
+			if err != nil {
+				f.log.StructuredError("fsm cleanup error", err)
+				continue
+			}
+			if deleted > 0 {
+				f.log.Infof("fsm cleanup: %d expired sessions deleted", deleted)
+			}
+		}
+	}
+}
+
+// load returns an empty session with non-nil data if there is none or it
+// has expired.
+func (f *FSM) load(ctx context.Context, userID int64, slot Slot) (Session, bool, error) {
+	empty := Session{Data: map[string]string{}}
+
+	s, ok, err := f.storage.Load(ctx, userID, slot)
+	if err != nil {
+		return empty, false, err
+	}
+	if !ok || time.Since(s.UpdatedAt) > f.ttl {
+		return empty, false, nil
+	}
+	if s.Data == nil {
+		s.Data = map[string]string{}
+	}
+	return s, true, nil
+}
+
+// delete only logs errors: a session left behind expires on its own.
+func (f *FSM) delete(ctx context.Context, userID int64, slot Slot) {
+	if err := f.storage.Delete(ctx, userID, slot); err != nil {
+		f.log.StructuredError("fsm delete error", err)
+	}
 }
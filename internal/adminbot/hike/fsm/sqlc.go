@@ -0,0 +1,76 @@
+package fsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/admin"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+type postgresStorage struct {
+	queries *admin.Queries
+}
+
+// NewPostgresStorage keeps sessions in admin_fsm_sessions, so drafts survive restarts.
+func NewPostgresStorage(q *admin.Queries) Storage {
+	return &postgresStorage{queries: q}
+}
+
+func (p *postgresStorage) Load(ctx context.Context, userID int64, slot Slot) (Session, bool, error) {
+	row, err := p.queries.GetFSMSession(ctx, admin.GetFSMSessionParams{
+		TgUserID: userID,
+		Slot:     string(slot),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Session{}, false, nil
+		}
+		return Session{}, false, logger.WrapError(err)
+	}
+
+	data := map[string]string{}
+	if err := json.Unmarshal(row.Data, &data); err != nil {
+		return Session{}, false, logger.WrapError(err)
+	}
+
+	return Session{
+		State:     State(row.State),
+		Data:      data,
+		UpdatedAt: row.UpdatedAt,
+	}, true, nil
+}
+
+func (p *postgresStorage) Save(ctx context.Context, userID int64, slot Slot, s Session) error {
+	data, err := json.Marshal(s.Data)
+	if err != nil {
+		return logger.WrapError(err)
+	}
+
+	err = p.queries.SaveFSMSession(ctx, admin.SaveFSMSessionParams{
+		TgUserID: userID,
+		Slot:     string(slot),
+		State:    string(s.State),
+		Data:     data,
+	})
+	return logger.WrapError(err)
+}
+
+func (p *postgresStorage) Delete(ctx context.Context, userID int64, slot Slot) error {
+	err := p.queries.DeleteFSMSession(ctx, admin.DeleteFSMSessionParams{
+		TgUserID: userID,
+		Slot:     string(slot),
+	})
+	return logger.WrapError(err)
+}
+
+func (p *postgresStorage) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := p.queries.DeleteExpiredFSMSessions(ctx, before)
+	if err != nil {
+		return 0, logger.WrapError(err)
+	}
+	return deleted, nil
+}
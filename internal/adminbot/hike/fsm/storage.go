@@ -0,0 +1,29 @@
+package fsm
+
+import (
+	"context"
+	"time"
+)
+
+// Slot separates the scenario an admin is currently in from a hike draft
+// parked for later.
+type Slot string
+
+const (
+	SlotActive Slot = "active"
+	SlotDraft  Slot = "draft"
+)
+
+type Session struct {
+	State     State
+	Data      map[string]string
+	UpdatedAt time.Time
+}
+
+type Storage interface {
+	// Load returns false if there is no session in the slot.
+	Load(ctx context.Context, userID int64, slot Slot) (Session, bool, error)
+	Save(ctx context.Context, userID int64, slot Slot, s Session) error
+	Delete(ctx context.Context, userID int64, slot Slot) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
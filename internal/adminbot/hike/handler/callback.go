@@ -2,15 +2,145 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/fsm"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
+func (h *HikeHandler) HandleCallback(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.Message == nil {
+		return nil
+	}
+
+	switch {
+	case strings.HasPrefix(q.Data, "hike:list:"):
+		return h.ShowHikesPage(ctx, q)
+
+	case strings.HasPrefix(q.Data, "hike:open:"):
+		return h.OpenHike(ctx, q)
+
+	case strings.HasPrefix(q.Data, "hike:card:"):
+		return h.HandleCardAction(ctx, q)
+
+	case q.Data == "hike:noop":
+		return h.answerCallback(q.ID, "")
+	}
+
+	return nil
+}
+
+// ShowHikesPage edits the hike list in place to show another page or filter.
+func (h *HikeHandler) ShowHikesPage(ctx context.Context, q *tgbot.CallbackQuery) error {
+	filter, page, ok := parseHikeListPage(q.Data)
+	if !ok {
+		return h.answerCallback(q.ID, "Некорректная страница")
+	}
+
+	hikes, err := h.service.ListHikes(ctx, filter, page, hikeUI.HikeListPageSize)
+	if err != nil {
+		_ = h.answerCallback(q.ID, "Не удалось загрузить список хайков")
+		return err
+	}
+
+	edit := tgbot.NewEditMessageTextAndMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		hikeUI.HikeListMessage(hikes),
+		hikeUI.HikeListKeyboard(hikes, h.loc),
+	)
+
+	if _, err := h.bot.Send(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.answerCallback(q.ID, "")
+}
+
+// OpenHike selects the tapped hike and shows the actions keyboard, leaving
+// the scenario the admin was in.
+func (h *HikeHandler) OpenHike(ctx context.Context, q *tgbot.CallbackQuery) error {
+	hikeID, err := strconv.ParseInt(strings.TrimPrefix(q.Data, "hike:open:"), 10, 32)
+	if err != nil {
+		return h.answerCallback(q.ID, "Некорректный ID хайка")
+	}
+
+	hike, err := h.service.GetHike(ctx, int32(hikeID))
+	if err != nil {
+		_ = h.answerCallback(q.ID, fmt.Sprintf("Хайк с ID %d не найден", hikeID))
+		return err
+	}
+
+	if err := h.answerCallback(q.ID, ""); err != nil {
+		return err
+	}
+
+	if err := h.LeaveFSM(ctx, q.From.ID); err != nil {
+		return err
+	}
+	if err := h.selectHike(ctx, q.From.ID, hike); err != nil {
+		return err
+	}
+
+	msg := tgbot.NewMessage(q.Message.Chat.ID, fmt.Sprintf("Выбран хайк: %s", hike.TitleRu))
+	msg.ReplyMarkup = hikeUI.SelectedHikeActionsKeyboard(hike.IsPublished)
+
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// HandleCardAction selects the hike of the card and runs the action as if the
+// admin pressed the same button of the selected hike keyboard.
+func (h *HikeHandler) HandleCardAction(ctx context.Context, q *tgbot.CallbackQuery) error {
+	action, hikeID, ok := parseHikeCardAction(q.Data)
+	if !ok {
+		return h.answerCallback(q.ID, "Некорректное действие")
+	}
+
+	hike, err := h.service.GetHike(ctx, hikeID)
+	if err != nil {
+		_ = h.answerCallback(q.ID, fmt.Sprintf("Хайк с ID %d не найден", hikeID))
+		return err
+	}
+
+	if err := h.answerCallback(q.ID, ""); err != nil {
+		return err
+	}
+
+	// The FSM scenarios key on the admin and reply to the chat of the card.
+	m := &tgbot.Message{From: q.From, Chat: q.Message.Chat}
+	if err := h.LeaveFSM(ctx, m.From.ID); err != nil {
+		return err
+	}
+	if err := h.selectHike(ctx, m.From.ID, hike); err != nil {
+		return err
+	}
+
+	switch action {
+	case "publish":
+		return h.askPublishHike(ctx, m, hike.TitleRu, hike.IsPublished)
+	case "hide":
+		return h.askHideHike(ctx, m, hike.TitleRu, hike.IsPublished)
+	case "edit":
+		return h.StartEditHike(ctx, m)
+	case "clone":
+		return h.StartCloneHike(ctx, m)
+	case "roster":
+		return h.sendRoster(ctx, m)
+	}
+
+	return nil
+}
+
 func (h *HikeHandler) HandleConfirm(ctx context.Context, q *tgbot.CallbackQuery) error {
 	userID := q.From.ID
 
-	if h.fsm.State(userID) != fsm.StateConfirm {
+	if h.fsm.State(ctx, userID) != fsm.StateConfirm {
 		return nil
 	}
 
@@ -19,7 +149,7 @@ func (h *HikeHandler) HandleConfirm(ctx context.Context, q *tgbot.CallbackQuery)
 		return err
 	}
 
-	h.fsm.Reset(userID)
+	h.fsm.Reset(ctx, userID)
 
 	// delete buttons
 	edit := tgbot.NewEditMessageReplyMarkup(
@@ -40,7 +170,7 @@ func (h *HikeHandler) HandleConfirm(ctx context.Context, q *tgbot.CallbackQuery)
 func (h *HikeHandler) HandleCancel(ctx context.Context, q *tgbot.CallbackQuery) error {
 	userID := q.From.ID
 
-	h.fsm.Reset(userID)
+	h.fsm.Reset(ctx, userID)
 
 	// delete buttons
 	edit := tgbot.NewEditMessageReplyMarkup(
@@ -57,3 +187,50 @@ func (h *HikeHandler) HandleCancel(ctx context.Context, q *tgbot.CallbackQuery)
 	_, err := h.bot.Send(tgbot.NewMessage(q.Message.Chat.ID, "Создание отменено."))
 	return err
 }
+
+func (h *HikeHandler) answerCallback(callbackID, text string) error {
+	cb := tgbot.NewCallback(callbackID, text)
+	_, err := h.bot.Request(cb)
+	return logger.WrapError(err)
+}
+
+func parseHikeCardAction(data string) (action string, hikeID int32, ok bool) {
+	// hike:card:publish:15
+
+	parts := strings.Split(data, ":")
+	if len(parts) != 4 {
+		return "", 0, false
+	}
+
+	if parts[0] != "hike" || parts[1] != "card" {
+		return "", 0, false
+	}
+
+	id64, err := strconv.ParseInt(parts[3], 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return parts[2], int32(id64), true
+}
+
+func parseHikeListPage(data string) (filter service.HikeFilter, page int32, ok bool) {
+	// hike:list:draft:2
+
+	parts := strings.Split(data, ":")
+	if len(parts) != 4 {
+		return "", 0, false
+	}
+
+	filter = service.HikeFilter(parts[2])
+	if !filter.Valid() {
+		return "", 0, false
+	}
+
+	page64, err := strconv.ParseInt(parts[3], 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return filter, int32(page64), true
+}
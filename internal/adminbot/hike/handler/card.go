@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/booking"
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// cardValueLimit cuts long texts so the card with both descriptions stays
+// below the Telegram message limit.
+const cardValueLimit = 700
+
+// sendHikeCard sends the photo of the selected hike and the card with all
+// its fields, bookings and quick actions.
+func (h *HikeHandler) sendHikeCard(ctx context.Context, m *tgbot.Message) error {
+	hike, err := h.selectedHike(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	summary, err := h.bookingService.HikeSummary(ctx, hike.ID)
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Не удалось загрузить заявки хайка."))
+		return err
+	}
+
+	var photoErr error
+	if hike.ImagePath != "" {
+		photo := tgbot.NewPhoto(m.Chat.ID, tgbot.FilePath(filepath.Join(h.storageRoot, hike.ImagePath)))
+		photo.Caption = hike.TitleRu
+		if _, err := h.bot.Send(photo); err != nil {
+			photoErr = logger.WrapError(err)
+		}
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, h.hikeCardText(hike)+"\n\n"+bookingUI.HikeSummaryBlock(summary))
+	msg.ParseMode = tgbot.ModeHTML
+	msg.ReplyMarkup = hikeUI.HikeCardKeyboard(hike.ID, hike.IsPublished)
+
+	if _, err := h.bot.Send(msg); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return photoErr
+}
+
+func (h *HikeHandler) hikeCardText(hike service.Hike) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("🧾 <b>Карточка хайка #%d</b>\n\n", hike.ID))
+
+	if hike.IsPublished {
+		sb.WriteString("Статус: 🟢 опубликован\n")
+	} else {
+		sb.WriteString("Статус: 🙈 скрыт\n")
+	}
+	if hike.SeriesID != 0 {
+		sb.WriteString(fmt.Sprintf("Серия: #%d\n", hike.SeriesID))
+	}
+	sb.WriteString("\n")
+
+	for _, f := range editFields {
+		sb.WriteString(fmt.Sprintf(
+			"<b>%s</b>: %s\n",
+			f.label,
+			html.EscapeString(truncateRunes(editFieldValue(hike, f.key, h.loc), cardValueLimit)),
+		))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nСоздан: %s\n", hike.CreatedAt.In(h.loc).Format("02.01.2006 15:04")))
+	sb.WriteString(fmt.Sprintf("Обновлён: %s", hike.UpdatedAt.In(h.loc).Format("02.01.2006 15:04")))
+
+	return sb.String()
+}
+
+func truncateRunes(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	return string([]rune(s)[:limit]) + "…"
+}
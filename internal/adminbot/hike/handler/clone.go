@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/fsm"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/parser"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// cloneRepeats maps repeat keyboard buttons to the interval between copies in weeks.
+var cloneRepeats = map[i18n.Key]int{
+	i18n.BtnAdminCloneOnce:     0,
+	i18n.BtnAdminCloneWeekly:   1,
+	i18n.BtnAdminCloneBiweekly: 2,
+}
+
+func (h *HikeHandler) StartCloneHike(ctx context.Context, m *tgbot.Message) error {
+	hike, err := h.selectedHike(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCloneDates, nil); err != nil {
+		return err
+	}
+
+	return h.sendEditStep(m.Chat.ID, fmt.Sprintf(
+		"Копия получит всё содержимое хайка, кроме дат. Она не будет опубликована.\n\nСейчас: %s\n\nВведите даты первой копии.\nПримеры: 10 · 10 12 · 10-12 · 31 3 · 03.02-04.02 · 15.12 16.12",
+		editFieldValue(hike, "dates", h.loc),
+	))
+}
+
+func (h *HikeHandler) HandleCloneHike(ctx context.Context, m *tgbot.Message) error {
+	txt := strings.TrimSpace(m.Text)
+	key, _ := i18n.KeyOf(txt)
+
+	if key == i18n.BtnAdminCancel {
+		return h.backToSelectedHikeActions(ctx, m)
+	}
+
+	switch h.fsm.State(ctx, m.From.ID) {
+	case fsm.StateCloneDates:
+		start, end, err := parser.ParseHikeDates(txt, time.Now().In(h.loc), h.loc)
+		if err != nil {
+			_ = h.sendEditStep(m.Chat.ID, "Не получилось распознать даты. Попробуйте ещё раз.\nПримеры: 10 · 10 12 · 10-12 · 31 3 · 03.02-04.02 · 15.12 16.12")
+			return nil
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCloneRepeat, map[string]string{
+			"clone_starts_at": start.Format("02.01.2006 15:04"),
+			"clone_ends_at":   end.Format("02.01.2006 15:04"),
+		}); err != nil {
+			return err
+		}
+
+		msg := tgbot.NewMessage(m.Chat.ID, "Создать одну копию или серию хайков с повтором?")
+		msg.ReplyMarkup = hikeUI.CloneRepeatKeyboard()
+
+		_, err = h.bot.Send(msg)
+		return err
+
+	case fsm.StateCloneRepeat:
+		weeks, ok := cloneRepeats[key]
+		if !ok {
+			msg := tgbot.NewMessage(m.Chat.ID, "Выберите вариант с помощью кнопок ниже.")
+			msg.ReplyMarkup = hikeUI.CloneRepeatKeyboard()
+
+			_, err := h.bot.Send(msg)
+			return err
+		}
+
+		if weeks == 0 {
+			return h.sendClonePreview(ctx, m, map[string]string{
+				"clone_weeks": "0",
+				"clone_count": "1",
+			})
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCloneCount, map[string]string{"clone_weeks": strconv.Itoa(weeks)}); err != nil {
+			return err
+		}
+		return h.sendEditStep(m.Chat.ID, fmt.Sprintf("Сколько хайков будет в серии? Введите число от 2 до %d.", service.MaxSeriesLength))
+
+	case fsm.StateCloneCount:
+		count, err := strconv.Atoi(txt)
+		if err != nil || count < 2 || count > service.MaxSeriesLength {
+			_ = h.sendEditStep(m.Chat.ID, fmt.Sprintf("Введите число от 2 до %d.", service.MaxSeriesLength))
+			return nil
+		}
+
+		return h.sendClonePreview(ctx, m, map[string]string{"clone_count": strconv.Itoa(count)})
+
+	case fsm.StateConfirmCloneHike:
+		if key != i18n.BtnAdminCloneConfirm {
+			msg := tgbot.NewMessage(m.Chat.ID, "Подтвердите создание или отмените действие.")
+			msg.ReplyMarkup = hikeUI.CloneConfirmKeyboard()
+
+			_, err := h.bot.Send(msg)
+			return err
+		}
+
+		return h.saveClonedHikes(ctx, m)
+	}
+
+	return nil
+}
+
+// cloneOccurrences builds the dates of the copies from the FSM data.
+func (h *HikeHandler) cloneOccurrences(data map[string]string) ([]service.Occurrence, error) {
+	startsAt, err := time.ParseInLocation("02.01.2006 15:04", data["clone_starts_at"], h.loc)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	endsAt, err := time.ParseInLocation("02.01.2006 15:04", data["clone_ends_at"], h.loc)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	weeks, err := strconv.Atoi(data["clone_weeks"])
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	count, err := strconv.Atoi(data["clone_count"])
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	first := service.Occurrence{StartsAt: startsAt, EndsAt: endsAt}
+	return service.Repeat(first, count, weeks), nil
+}
+
+// sendClonePreview stores the last answer together with the confirmation state.
+func (h *HikeHandler) sendClonePreview(ctx context.Context, m *tgbot.Message, values map[string]string) error {
+	data := h.fsm.Data(ctx, m.From.ID)
+	for k, v := range values {
+		data[k] = v
+	}
+
+	occurrences, err := h.cloneOccurrences(data)
+	if err != nil {
+		h.fsm.Reset(ctx, m.From.ID)
+		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Не удалось подготовить копии. Состояние сброшено."))
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateConfirmCloneHike, values); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	if len(occurrences) == 1 {
+		fmt.Fprintf(&b, "Будет создана копия хайка «%s»:\n\n", data["selected_hike_title"])
+	} else {
+		fmt.Fprintf(&b, "Будет создана серия из %d хайков «%s»:\n\n", len(occurrences), data["selected_hike_title"])
+	}
+	for _, o := range occurrences {
+		fmt.Fprintf(&b, "• %s → %s\n", o.StartsAt.Format("02.01.2006 15:04"), o.EndsAt.Format("02.01.2006 15:04"))
+	}
+	b.WriteString("\nКопии не будут опубликованы.")
+
+	msg := tgbot.NewMessage(m.Chat.ID, b.String())
+	msg.ReplyMarkup = hikeUI.CloneConfirmKeyboard()
+
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// saveClonedHikes creates the copies and gives each of them the image of the source hike.
+// The copies are kept even if some image couldn't be copied.
+func (h *HikeHandler) saveClonedHikes(ctx context.Context, m *tgbot.Message) error {
+	source, err := h.selectedHike(ctx, m)
+	if err != nil {
+		return err
+	}
+
+	occurrences, err := h.cloneOccurrences(h.fsm.Data(ctx, m.From.ID))
+	if err != nil {
+		h.fsm.Reset(ctx, m.From.ID)
+		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Не удалось подготовить копии. Состояние сброшено."))
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+
+	copies, err := h.service.CloneHike(ctx, source, occurrences)
+	if err != nil {
+		msg := tgbot.NewMessage(m.Chat.ID, "Не удалось создать копии хайка.")
+		msg.ReplyMarkup = hikeUI.CloneConfirmKeyboard()
+
+		_, _ = h.bot.Send(msg)
+		return err
+	}
+
+	var imageErr error
+	if source.ImagePath != "" {
+		for _, c := range copies {
+			if imageErr = h.copyImage(ctx, source.ImagePath, c.ID); imageErr != nil {
+				break
+			}
+		}
+	}
+
+	h.fsm.Reset(ctx, m.From.ID)
+
+	var b strings.Builder
+	b.WriteString("Копии созданы ✅ Они не опубликованы.\n\n")
+	for _, c := range copies {
+		fmt.Fprintf(&b, "• ID %d — %s\n", c.ID, c.StartsAt.In(h.loc).Format("02.01.2006 15:04"))
+	}
+	if imageErr != nil {
+		b.WriteString("\n⚠️ Не удалось скопировать фото, загрузите его через редактирование.")
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, b.String())
+	msg.ReplyMarkup = hikeUI.HikeMenu()
+
+	if _, sendErr := h.bot.Send(msg); sendErr != nil && imageErr == nil {
+		return sendErr
+	}
+
+	return imageErr
+}
+
+// copyImage copies the stored image of a hike for a new hike and saves its path.
+func (h *HikeHandler) copyImage(ctx context.Context, srcPath string, hikeID int32) error {
+	in, err := os.Open(filepath.Join(h.storageRoot, srcPath))
+	if err != nil {
+		return logger.WrapError(err)
+	}
+	defer in.Close()
+
+	imagePath := fmt.Sprintf("hikes/%d.jpg", hikeID)
+
+	out, err := os.Create(filepath.Join(h.storageRoot, imagePath))
+	if err != nil {
+		return logger.WrapError(err)
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, in); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.service.UpdateImagePath(ctx, hikeID, imagePath)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// sendDifficultyStep asks for the level of a new hike, suggesting the one
+// its length and elevation gain point to.
+func (h *HikeHandler) sendDifficultyStep(ctx context.Context, chatID, userID int64) error {
+	data := h.fsm.Data(ctx, userID)
+	suggested := suggestedDifficulty(data)
+
+	msg := tgbot.NewMessage(chatID, fmt.Sprintf(
+		"Выберите сложность маршрута. По длине %s км и набору %s м предлагаю: %s",
+		data["distance_km"],
+		data["elevation_gain_m"],
+		hikeUI.DifficultyLabel(suggested),
+	))
+	msg.ReplyMarkup = hikeUI.DifficultyKeyboard(suggested)
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func suggestedDifficulty(data map[string]string) trail.Difficulty {
+	distanceKm, _ := strconv.ParseFloat(data["distance_km"], 64)
+	elevationGainM, _ := strconv.Atoi(data["elevation_gain_m"])
+
+	return trail.SuggestDifficulty(distanceKm, elevationGainM)
+}
+
+// draftDifficulty returns the chosen level of a new hike, drafts started
+// before the difficulty step get the suggested one.
+func draftDifficulty(data map[string]string) trail.Difficulty {
+	if d := trail.Difficulty(data["difficulty"]); d.Valid() {
+		return d
+	}
+	return suggestedDifficulty(data)
+}
+
+func (h *HikeHandler) sendTagsStep(ctx context.Context, chatID, userID int64, text string) error {
+	msg := tgbot.NewMessage(chatID, text)
+	msg.ReplyMarkup = hikeUI.TagsKeyboard(tagsFromData(h.fsm.Data(ctx, userID)["tags"]))
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+// tagsFromData reads the tags kept in the FSM data as a comma separated list.
+func tagsFromData(value string) []trail.Tag {
+	var tags []trail.Tag
+	for _, t := range strings.Split(value, ",") {
+		if tag := trail.Tag(t); tag.Valid() {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func tagsToData(tags []trail.Tag) string {
+	values := make([]string, 0, len(tags))
+	for _, t := range tags {
+		values = append(values, string(t))
+	}
+	return strings.Join(values, ",")
+}
+
+// toggleTag adds the tag or removes it if the hike already has it.
+func toggleTag(tags []trail.Tag, tag trail.Tag) []trail.Tag {
+	for i, t := range tags {
+		if t == tag {
+			return append(tags[:i:i], tags[i+1:]...)
+		}
+	}
+	return append(tags, tag)
+}
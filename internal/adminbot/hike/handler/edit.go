@@ -0,0 +1,635 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/fsm"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/parser"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// editFields maps edit keyboard buttons to the hike fields they change.
+var editFields = []struct {
+	key   string
+	label string
+}{
+	{"title_ru", "🏔 Название"},
+	{"preview_ru", "🔎 Превью"},
+	{"description_ru", "📝 Описание"},
+	{"price_gel", "💰 Цена"},
+	{"distance_km", "📏 Длина"},
+	{"elevation_gain_m", "⛰ Набор высоты"},
+	{"difficulty", "🎚 Сложность"},
+	{"tags", "🏷 Теги"},
+	{"max_participants", "👥 Мест"},
+	{"dates", "🗓 Даты"},
+	{"photo", "📷 Фото"},
+	{"title_en", "🇬🇧 Название"},
+	{"preview_en", "🇬🇧 Превью"},
+	{"description_en", "🇬🇧 Описание"},
+	{"publish_at", "📢 Публикация"},
+	{"unpublish_at", "🔒 Закрытие записи"},
+}
+
+func editFieldByLabel(label string) (string, bool) {
+	for _, f := range editFields {
+		if f.label == label {
+			return f.key, true
+		}
+	}
+	return "", false
+}
+
+func editFieldLabel(key string) string {
+	for _, f := range editFields {
+		if f.key == key {
+			return f.label
+		}
+	}
+	return key
+}
+
+func (h *HikeHandler) StartEditHike(ctx context.Context, m *tgbot.Message) error {
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateEditHikeField, nil); err != nil {
+		return err
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, "Какое поле хотите изменить?")
+	msg.ReplyMarkup = hikeUI.EditHikeFieldsKeyboard()
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *HikeHandler) HandleEditHike(ctx context.Context, m *tgbot.Message) error {
+	txt := strings.TrimSpace(m.Text)
+	key, _ := i18n.KeyOf(txt)
+
+	switch h.fsm.State(ctx, m.From.ID) {
+	case fsm.StateEditHikeField:
+		if key == i18n.BtnAdminCancel {
+			return h.backToSelectedHikeActions(ctx, m)
+		}
+
+		field, ok := editFieldByLabel(txt)
+		if !ok {
+			msg := tgbot.NewMessage(m.Chat.ID, "Выберите поле с помощью кнопок ниже.")
+			msg.ReplyMarkup = hikeUI.EditHikeFieldsKeyboard()
+
+			_, err := h.bot.Send(msg)
+			return err
+		}
+
+		hike, err := h.selectedHike(ctx, m)
+		if err != nil {
+			return err
+		}
+
+		if field == "publish_at" && hike.IsPublished {
+			msg := tgbot.NewMessage(m.Chat.ID, "Хайк уже опубликован. Чтобы опубликовать его по расписанию, сначала скройте его.")
+			msg.ReplyMarkup = hikeUI.EditHikeFieldsKeyboard()
+
+			_, err := h.bot.Send(msg)
+			return err
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateEditHikeValue, map[string]string{"edit_field": field}); err != nil {
+			return err
+		}
+
+		return h.sendEditValueStep(m.Chat.ID, field, fmt.Sprintf(
+			"Текущее значение:\n%s\n\n%s",
+			editFieldValue(hike, field, h.loc),
+			editFieldPrompt(field),
+		))
+
+	case fsm.StateEditHikeValue:
+		if key == i18n.BtnAdminCancel {
+			return h.StartEditHike(ctx, m)
+		}
+
+		data := h.fsm.Data(ctx, m.From.ID)
+		field := data["edit_field"]
+		values, prompt, ok := h.parseEditValue(m, field)
+		if !ok {
+			return h.sendEditValueStep(m.Chat.ID, field, prompt)
+		}
+		for k, v := range values {
+			data[k] = v
+		}
+
+		hike, err := h.selectedHike(ctx, m)
+		if err != nil {
+			return err
+		}
+
+		updated := hike
+		if err := applyEditValue(&updated, field, data, h.loc); err != nil {
+			return h.sendEditStep(m.Chat.ID, "Не удалось применить значение. Попробуйте ещё раз.")
+		}
+
+		if field == "publish_at" || field == "unpublish_at" {
+			problem := checkSchedule(updated.PublishAt, updated.UnpublishAt, updated.EndsAt, time.Now())
+			if problem != "" {
+				return h.sendEditStep(m.Chat.ID, problem)
+			}
+		}
+
+		clientCaptionLen := countClientCaption(hikeCaptionData(updated, h.loc))
+		if clientCaptionLen > 1024 {
+			return h.sendEditStep(
+				m.Chat.ID,
+				fmt.Sprintf(
+					"Итоговый Telegram caption слишком длинный: %d символов из 1024 допустимых. Сократите текст.",
+					clientCaptionLen,
+				),
+			)
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateConfirmEditHike, values); err != nil {
+			return err
+		}
+
+		preview := fmt.Sprintf(
+			"✏️ <b>Проверьте изменения</b>\n\n"+
+				"Поле: %s\n\n"+
+				"<b>Было:</b>\n%s\n\n"+
+				"<b>Стало:</b>\n%s\n\n"+
+				"📐 Общий Telegram caption: %d / 1024",
+			editFieldLabel(field),
+			html.EscapeString(editFieldValue(hike, field, h.loc)),
+			html.EscapeString(editFieldValue(updated, field, h.loc)),
+			clientCaptionLen,
+		)
+
+		msg := tgbot.NewMessage(m.Chat.ID, preview)
+		msg.ParseMode = tgbot.ModeHTML
+		msg.ReplyMarkup = hikeUI.EditConfirmKeyboard()
+
+		_, err = h.bot.Send(msg)
+		return err
+
+	case fsm.StateConfirmEditHike:
+		switch key {
+		case i18n.BtnAdminSave:
+			return h.saveEditedHike(ctx, m)
+
+		case i18n.BtnAdminCancel:
+			return h.backToSelectedHikeActions(ctx, m)
+
+		default:
+			msg := tgbot.NewMessage(m.Chat.ID, "Сохраните изменения или отмените действие.")
+			msg.ReplyMarkup = hikeUI.EditConfirmKeyboard()
+
+			_, err := h.bot.Send(msg)
+			return err
+		}
+	}
+
+	h.fsm.Reset(ctx, m.From.ID)
+	_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Неизвестное состояние. Сбросил сценарий."))
+	return err
+}
+
+func (h *HikeHandler) sendEditStep(chatID int64, text string) error {
+	msg := tgbot.NewMessage(chatID, text)
+	msg.ReplyMarkup = hikeUI.EditHikeValueKeyboard()
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+// sendEditValueStep asks for the new value of the field, the difficulty is
+// chosen with buttons.
+func (h *HikeHandler) sendEditValueStep(chatID int64, field, text string) error {
+	if field != "difficulty" {
+		return h.sendEditStep(chatID, text)
+	}
+
+	msg := tgbot.NewMessage(chatID, text)
+	msg.ReplyMarkup = hikeUI.EditDifficultyKeyboard()
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *HikeHandler) selectedHike(ctx context.Context, m *tgbot.Message) (service.Hike, error) {
+	hikeID, err := strconv.Atoi(h.fsm.Data(ctx, m.From.ID)["selected_hike_id"])
+	if err != nil {
+		h.fsm.Reset(ctx, m.From.ID)
+		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Некорректный ID хайка. Состояние сброшено."))
+		if sendErr != nil {
+			return service.Hike{}, sendErr
+		}
+		return service.Hike{}, err
+	}
+
+	hike, err := h.service.GetHike(ctx, int32(hikeID))
+	if err != nil {
+		h.fsm.Reset(ctx, m.From.ID)
+		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, fmt.Sprintf("Хайк с ID %d не найден.", hikeID)))
+		if sendErr != nil {
+			return service.Hike{}, sendErr
+		}
+		return service.Hike{}, err
+	}
+
+	return hike, nil
+}
+
+// parseEditValue validates the admin input for the field and returns the FSM
+// values to store. It returns a prompt to repeat when the input is not valid.
+func (h *HikeHandler) parseEditValue(m *tgbot.Message, field string) (map[string]string, string, bool) {
+	txt := strings.TrimSpace(m.Text)
+
+	switch field {
+	case "title_ru", "description_ru":
+		if txt == "" {
+			return nil, "Значение не может быть пустым.", false
+		}
+		return map[string]string{"edit_value": txt}, "", true
+
+	case "preview_ru":
+		if txt == "" {
+			return nil, "Введите краткое описание для превью.", false
+		}
+		if count := utf8.RuneCountInString(txt); count > 1024 {
+			return nil, fmt.Sprintf("Превью слишком длинное: %d символов из 1024 допустимых. Сократите текст.", count), false
+		}
+		return map[string]string{"edit_value": txt}, "", true
+
+	case "title_en", "description_en", "preview_en":
+		// "-" drops the translation
+		if txt == "-" {
+			txt = ""
+		}
+		if count := utf8.RuneCountInString(txt); field == "preview_en" && count > 1024 {
+			return nil, fmt.Sprintf("Превью слишком длинное: %d символов из 1024 допустимых. Сократите текст.", count), false
+		}
+		return map[string]string{"edit_value": txt}, "", true
+
+	case "price_gel":
+		price, err := strconv.Atoi(txt)
+		if err != nil || price < 0 {
+			return nil, "Введите корректную цену в лари целым числом. Например: 120", false
+		}
+		return map[string]string{"edit_value": strconv.Itoa(price)}, "", true
+
+	case "distance_km":
+		distance, err := strconv.ParseFloat(strings.ReplaceAll(txt, ",", "."), 64)
+		if err != nil || distance < 0 {
+			return nil, "Введите корректную длину маршрута. Например: 8.5", false
+		}
+		return map[string]string{"edit_value": strconv.FormatFloat(distance, 'f', 2, 64)}, "", true
+
+	case "elevation_gain_m":
+		elevationGain, err := strconv.Atoi(txt)
+		if err != nil || elevationGain < 0 {
+			return nil, "Введите корректный набор высоты в метрах. Например: 650", false
+		}
+		return map[string]string{"edit_value": strconv.Itoa(elevationGain)}, "", true
+
+	case "difficulty":
+		difficulty, ok := hikeUI.DifficultyByText(txt)
+		if !ok {
+			return nil, "Выберите сложность кнопками ниже.", false
+		}
+		return map[string]string{"edit_value": string(difficulty)}, "", true
+
+	case "tags":
+		// "-" drops all tags
+		var tags []trail.Tag
+		if txt != "-" {
+			var ok bool
+			if tags, ok = hikeUI.TagsByText(txt); !ok {
+				return nil, "Не получилось распознать теги. " + editFieldPrompt(field), false
+			}
+		}
+		return map[string]string{"edit_value": tagsToData(tags)}, "", true
+
+	case "max_participants":
+		maxParticipants, err := strconv.Atoi(txt)
+		if err != nil || maxParticipants < 0 {
+			return nil, "Введите количество участников целым числом. Например: 12 (0 — без ограничений)", false
+		}
+		return map[string]string{"edit_value": strconv.Itoa(maxParticipants)}, "", true
+
+	case "dates":
+		start, end, err := parser.ParseHikeDates(txt, time.Now().In(h.loc), h.loc)
+		if err != nil {
+			return nil, "Не получилось распознать даты. Попробуйте ещё раз.\nПримеры: 10 · 10 12 · 10-12 · 31 3 · 03.02-04.02 · 15.12 16.12", false
+		}
+		return map[string]string{
+			"edit_starts_at": start.Format("02.01.2006 15:04"),
+			"edit_ends_at":   end.Format("02.01.2006 15:04"),
+		}, "", true
+
+	case "photo":
+		if len(m.Photo) == 0 {
+			return nil, "Пожалуйста, отправьте именно фото.", false
+		}
+		return map[string]string{"edit_value": m.Photo[len(m.Photo)-1].FileID}, "", true
+
+	case "publish_at", "unpublish_at":
+		// "-" drops the schedule
+		value := ""
+		if txt != "-" {
+			t, err := parser.ParseDateTime(txt, time.Now().In(h.loc), h.loc)
+			if err != nil {
+				return nil, "Не получилось распознать дату и время. " + scheduleFormatHint, false
+			}
+			value = t.Format("02.01.2006 15:04")
+		}
+		return map[string]string{"edit_value": value}, "", true
+
+	default:
+		return nil, "Неизвестное поле.", false
+	}
+}
+
+// editSaveAttempts is how many times an edit is re-applied when the hike
+// changes between reading and saving it.
+const editSaveAttempts = 3
+
+func (h *HikeHandler) saveEditedHike(ctx context.Context, m *tgbot.Message) error {
+	data := h.fsm.Data(ctx, m.From.ID)
+	field := data["edit_field"]
+
+	var (
+		updated   service.Hike
+		notifyErr error
+	)
+	// The edit is applied to a fresh copy of the hike, so a publish or hide
+	// made in the meantime isn't overwritten; a concurrent change is retried.
+	for attempt := 0; attempt < editSaveAttempts; attempt++ {
+		hike, err := h.selectedHike(ctx, m)
+		if err != nil {
+			return err
+		}
+
+		if err := applyEditValue(&hike, field, data, h.loc); err != nil {
+			_ = h.sendEditStep(m.Chat.ID, "Ошибка при сохранении хайка :(")
+			return err
+		}
+
+		// The hike is saved even if some of the booked clients weren't notified
+		updated, notifyErr = h.service.UpdateHike(ctx, hike)
+		if !errors.Is(notifyErr, service.ErrHikeChanged) {
+			break
+		}
+	}
+	if notifyErr != nil &&
+		!errors.Is(notifyErr, service.ErrClientNotification) &&
+		!errors.Is(notifyErr, service.ErrWaitlistPromotion) {
+		_ = h.sendEditStep(m.Chat.ID, "Ошибка при сохранении хайка :(")
+		return notifyErr
+	}
+
+	if field == "photo" {
+		imagePath, err := h.saveImage(ctx, updated.PhotoFileID, updated.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := h.service.UpdateImagePath(ctx, updated.ID, imagePath); err != nil {
+			return err
+		}
+	}
+
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateSelectedHikeAction, map[string]string{"selected_hike_title": updated.TitleRu}); err != nil {
+		return err
+	}
+
+	text := fmt.Sprintf(
+		"Хайк обновлён ✅\n\nИзменено: %s\nОбновлён: %s",
+		editFieldLabel(field),
+		updated.UpdatedAt.In(h.loc).Format("02.01.2006 15:04"),
+	)
+	if errors.Is(notifyErr, service.ErrClientNotification) {
+		text += "\n\n⚠️ Не всех записавшихся клиентов удалось уведомить об изменениях."
+	}
+	if errors.Is(notifyErr, service.ErrWaitlistPromotion) {
+		text += "\n\n⚠️ Не удалось передать все новые места листу ожидания."
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, text)
+	msg.ReplyMarkup = hikeUI.SelectedHikeActionsKeyboard(updated.IsPublished)
+
+	if _, err := h.bot.Send(msg); err != nil {
+		return err
+	}
+	return notifyErr
+}
+
+func applyEditValue(hike *service.Hike, field string, data map[string]string, loc *time.Location) error {
+	value := data["edit_value"]
+
+	switch field {
+	case "title_ru":
+		hike.TitleRu = value
+
+	case "preview_ru":
+		hike.PreviewRu = value
+
+	case "description_ru":
+		hike.DescriptionRu = value
+
+	case "title_en":
+		hike.TitleEn = value
+
+	case "preview_en":
+		hike.PreviewEn = value
+
+	case "description_en":
+		hike.DescriptionEn = value
+
+	case "price_gel":
+		price, err := strconv.Atoi(value)
+		if err != nil {
+			return logger.WrapError(err)
+		}
+		hike.PriceGel = int32(price)
+
+	case "distance_km":
+		distance, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return logger.WrapError(err)
+		}
+		hike.DistanceKm = distance
+
+	case "elevation_gain_m":
+		elevationGain, err := strconv.Atoi(value)
+		if err != nil {
+			return logger.WrapError(err)
+		}
+		hike.ElevationGainM = elevationGain
+
+	case "difficulty":
+		difficulty := trail.Difficulty(value)
+		if !difficulty.Valid() {
+			return logger.WrapError(fmt.Errorf("invalid difficulty %q", value))
+		}
+		hike.Difficulty = difficulty
+
+	case "tags":
+		hike.Tags = tagsFromData(value)
+
+	case "max_participants":
+		maxParticipants, err := strconv.Atoi(value)
+		if err != nil {
+			return logger.WrapError(err)
+		}
+		hike.MaxParticipants = int32(maxParticipants)
+
+	case "dates":
+		startsAt, err := time.ParseInLocation("02.01.2006 15:04", data["edit_starts_at"], loc)
+		if err != nil {
+			return logger.WrapError(err)
+		}
+		endsAt, err := time.ParseInLocation("02.01.2006 15:04", data["edit_ends_at"], loc)
+		if err != nil {
+			return logger.WrapError(err)
+		}
+		hike.StartsAt = startsAt
+		hike.EndsAt = endsAt
+
+	case "photo":
+		if value == "" {
+			return logger.WrapError(errors.New("photo file id is empty"))
+		}
+		hike.PhotoFileID = value
+
+	case "publish_at":
+		publishAt, err := scheduleFromData(value, loc)
+		if err != nil {
+			return err
+		}
+		hike.PublishAt = publishAt
+
+	case "unpublish_at":
+		unpublishAt, err := scheduleFromData(value, loc)
+		if err != nil {
+			return err
+		}
+		hike.UnpublishAt = unpublishAt
+
+	default:
+		return logger.WrapError(fmt.Errorf("unknown hike field %q", field))
+	}
+
+	return nil
+}
+
+func editFieldValue(hike service.Hike, field string, loc *time.Location) string {
+	switch field {
+	case "title_ru":
+		return hike.TitleRu
+	case "preview_ru":
+		return hike.PreviewRu
+	case "description_ru":
+		return hike.DescriptionRu
+	case "title_en":
+		return orDefault(hike.TitleEn, "нет перевода")
+	case "preview_en":
+		return orDefault(hike.PreviewEn, "нет перевода")
+	case "description_en":
+		return orDefault(hike.DescriptionEn, "нет перевода")
+	case "price_gel":
+		return fmt.Sprintf("%d GEL", hike.PriceGel)
+	case "distance_km":
+		return fmt.Sprintf("%.2f км", hike.DistanceKm)
+	case "elevation_gain_m":
+		return fmt.Sprintf("%d м", hike.ElevationGainM)
+	case "difficulty":
+		return hikeUI.DifficultyLabel(hike.Difficulty)
+	case "tags":
+		return hikeUI.TagsLabel(hike.Tags)
+	case "max_participants":
+		return formatMaxParticipants(strconv.Itoa(int(hike.MaxParticipants)))
+	case "dates":
+		return fmt.Sprintf(
+			"%s → %s",
+			hike.StartsAt.In(loc).Format("02.01.2006 15:04"),
+			hike.EndsAt.In(loc).Format("02.01.2006 15:04"),
+		)
+	case "photo":
+		if hike.PhotoFileID == "" {
+			return "нет фото"
+		}
+		return "фото загружено"
+	case "publish_at":
+		return formatPublishAt(hike.PublishAt, loc)
+	case "unpublish_at":
+		return formatUnpublishAt(hike.UnpublishAt, loc)
+	default:
+		return "—"
+	}
+}
+
+func editFieldPrompt(field string) string {
+	switch field {
+	case "title_ru":
+		return "Введите новое название RU:"
+	case "preview_ru":
+		return "Введите новое превью RU (1024 символа):"
+	case "description_ru":
+		return "Введите новое описание RU:"
+	case "title_en":
+		return "Введите новое название EN.\nОтправьте «-», чтобы убрать перевод."
+	case "preview_en":
+		return "Введите новое превью EN (1024 символа).\nОтправьте «-», чтобы убрать перевод."
+	case "description_en":
+		return "Введите новое описание EN.\nОтправьте «-», чтобы убрать перевод."
+	case "price_gel":
+		return "Введите новую цену в лари (например: 120):"
+	case "distance_km":
+		return "Введите новую длину маршрута в км (например: 8.5):"
+	case "elevation_gain_m":
+		return "Введите новый набор высоты в метрах (например: 650):"
+	case "difficulty":
+		return "Выберите новую сложность:"
+	case "tags":
+		return "Перечислите теги через запятую: водопад, многодневный, море, для семьи.\nОтправьте «-», чтобы убрать все теги."
+	case "max_participants":
+		return "Введите новое максимальное количество участников (0 — без ограничений):"
+	case "dates":
+		return "Введите новые даты (примеры: 10, 10 12, 10-12, 31 3, 03.02-04.02, 15.12 16.12)."
+	case "photo":
+		return "Загрузите новое фото:"
+	case "publish_at":
+		return "Когда опубликовать хайк? " + scheduleFormatHint + "\nОтправьте «-», чтобы публиковать вручную."
+	case "unpublish_at":
+		return "Когда закрыть запись? " + scheduleFormatHint + "\nОтправьте «-», чтобы держать запись открытой до окончания хайка."
+	default:
+		return "Введите новое значение:"
+	}
+}
+
+// hikeCaptionData converts a stored hike to the FSM data format used by countClientCaption.
+func hikeCaptionData(hike service.Hike, loc *time.Location) map[string]string {
+	return map[string]string{
+		"title_ru":         hike.TitleRu,
+		"preview_ru":       hike.PreviewRu,
+		"title_en":         hike.TitleEn,
+		"preview_en":       hike.PreviewEn,
+		"price_gel":        strconv.Itoa(int(hike.PriceGel)),
+		"distance_km":      strconv.FormatFloat(hike.DistanceKm, 'f', 2, 64),
+		"elevation_gain_m": strconv.Itoa(hike.ElevationGainM),
+		"difficulty":       string(hike.Difficulty),
+		"tags":             tagsToData(hike.Tags),
+		"starts_at":        hike.StartsAt.In(loc).Format("02.01.2006 15:04"),
+		"ends_at":          hike.EndsAt.In(loc).Format("02.01.2006 15:04"),
+	}
+}
@@ -7,22 +7,33 @@ import (
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/fsm"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
 )
 
 type HikeHandler struct {
-	bot         *tgbot.BotAPI
-	fsm         *fsm.FSM
-	service     service.Service
-	storageRoot string
-	loc         *time.Location
+	bot            *tgbot.BotAPI
+	fsm            *fsm.FSM
+	service        service.Service
+	bookingService bookingService.Service
+	storageRoot    string
+	loc            *time.Location
 }
 
-func New(b *tgbot.BotAPI, s service.Service, sroot string, l *time.Location) *HikeHandler {
+func New(
+	b *tgbot.BotAPI,
+	f *fsm.FSM,
+	s service.Service,
+	bS bookingService.Service,
+	sroot string,
+	l *time.Location,
+) *HikeHandler {
 	return &HikeHandler{
-		bot:         b,
-		fsm:         fsm.NewFSM(),
-		service:     s,
-		storageRoot: sroot,
-		loc:         l,
+		bot:            b,
+		fsm:            f,
+		service:        s,
+		bookingService: bS,
+		storageRoot:    sroot,
+		loc:            l,
 	}
 }
@@ -13,43 +13,71 @@ import (
 	"time"
 	"unicode/utf8"
 
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/fsm"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/parser"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/booking"
 	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
-func (h *HikeHandler) InProgressFSM(userID int64) bool {
-	return h.fsm.State(userID) != fsm.StateIdle
+func (h *HikeHandler) InProgressFSM(ctx context.Context, userID int64) bool {
+	return h.fsm.State(ctx, userID) != fsm.StateIdle
 }
 
-func (h *HikeHandler) ResetFSM(userID int64) {
-	h.fsm.Reset(userID)
+// LeaveFSM drops the current scenario. An unfinished hike draft is parked
+// instead, so StartCreateHike can offer to continue it.
+func (h *HikeHandler) LeaveFSM(ctx context.Context, userID int64) error {
+	if h.fsm.State(ctx, userID).IsCreate() {
+		return h.fsm.ParkDraft(ctx, userID)
+	}
+	h.fsm.Reset(ctx, userID)
+	return nil
 }
 
 func (h *HikeHandler) HandleFSM(ctx context.Context, m *tgbot.Message) error {
-	switch h.fsm.State(m.From.ID) {
+	switch h.fsm.State(ctx, m.From.ID) {
 	case fsm.StateCreateTitleRU,
+		fsm.StateCreateTitleEN,
 		fsm.StateCreatePreviewRU,
+		fsm.StateCreatePreviewEN,
 		fsm.StateCreateDescRU,
+		fsm.StateCreateDescEN,
 		fsm.StateCreatePrice,
 		fsm.StateCreateDistanceKm,
 		fsm.StateCreateElevationGain,
+		fsm.StateCreateDifficulty,
+		fsm.StateCreateTags,
+		fsm.StateCreateMaxParticipants,
 		fsm.StateCreateDates,
 		fsm.StateCreatePhoto,
+		fsm.StateCreatePublishAt,
+		fsm.StateCreateUnpublishAt,
 		fsm.StateConfirm:
 		return h.HandleCreateHike(ctx, m)
 
-	case fsm.StateSelectHikeID:
-		return h.HandleSelectHike(ctx, m)
-
-	case fsm.StateSelectedHikeAction, fsm.StateConfirmPublishHike, fsm.StateConfirmHideHike:
+	case fsm.StateSelectedHikeAction,
+		fsm.StateConfirmPublishHike,
+		fsm.StateConfirmHideHike,
+		fsm.StateCancelHikeReason,
+		fsm.StateConfirmCancelHike:
 		return h.HandlePublishHike(ctx, m)
 
+	case fsm.StateEditHikeField, fsm.StateEditHikeValue, fsm.StateConfirmEditHike:
+		return h.HandleEditHike(ctx, m)
+
+	case fsm.StateCloneDates, fsm.StateCloneRepeat, fsm.StateCloneCount, fsm.StateConfirmCloneHike:
+		return h.HandleCloneHike(ctx, m)
+
+	case fsm.StateContinueDraft:
+		return h.HandleContinueDraft(ctx, m)
+
 	default:
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 		_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Состояние сброшено."))
 		return err
 	}
@@ -72,95 +100,172 @@ func (h *HikeHandler) ShowMenu(ctx context.Context, m *tgbot.Message) error {
 }
 
 func (h *HikeHandler) StartCreateHike(ctx context.Context, m *tgbot.Message) error {
-	h.fsm.Reset(m.From.ID)
-	h.fsm.Set(m.From.ID, fsm.StateCreateTitleRU)
-	return h.sendCreateStep(m.Chat.ID, "Введите название RU:")
-}
+	h.fsm.Reset(ctx, m.From.ID)
 
-func (h *HikeHandler) HandleSelectHike(ctx context.Context, m *tgbot.Message) error {
-	txt := strings.TrimSpace(m.Text)
+	if draft, ok := h.fsm.Draft(ctx, m.From.ID); ok {
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateContinueDraft, nil); err != nil {
+			return err
+		}
 
-	if txt == "⬅️ Назад" {
-		h.fsm.Reset(m.From.ID)
-		return h.ShowMenu(ctx, m)
-	}
+		title := draft.Data["title_ru"]
+		if title == "" {
+			title = "без названия"
+		}
 
-	hikeID, err := strconv.Atoi(txt)
-	if err != nil {
-		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Введите корректный ID хайка."))
-		return nil
+		msg := tgbot.NewMessage(
+			m.Chat.ID,
+			fmt.Sprintf(
+				"У вас есть незавершённый черновик хайка «%s» от %s.\n\nПродолжить его?",
+				title,
+				draft.UpdatedAt.In(h.loc).Format("02.01.2006 15:04"),
+			),
+		)
+		msg.ReplyMarkup = hikeUI.ContinueDraftKeyboard()
+
+		_, err := h.bot.Send(msg)
+		return err
 	}
 
-	hike, err := h.service.GetHike(ctx, int32(hikeID))
-	if err != nil {
-		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, fmt.Sprintf("Хайк с ID %d не найден.", hikeID)))
-		return nil
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateTitleRU, nil); err != nil {
+		return err
 	}
+	return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreateTitleRU))
+}
 
-	h.fsm.Put(m.From.ID, "selected_hike_id", fmt.Sprintf("%d", hike.ID))
-	h.fsm.Put(m.From.ID, "selected_hike_title", hike.TitleRu)
-	h.fsm.Put(m.From.ID, "selected_hike_is_published", strconv.FormatBool(hike.IsPublished))
-	h.fsm.Set(m.From.ID, fsm.StateSelectedHikeAction)
+func (h *HikeHandler) HandleContinueDraft(ctx context.Context, m *tgbot.Message) error {
+	switch strings.TrimSpace(m.Text) {
+	case "▶️ Продолжить черновик":
+		state, ok, err := h.fsm.RestoreDraft(ctx, m.From.ID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateTitleRU, nil); err != nil {
+				return err
+			}
+			return h.sendCreateStep(m.Chat.ID, "Черновик не найден. "+createStepPrompt(fsm.StateCreateTitleRU))
+		}
 
-	msg := tgbot.NewMessage(m.Chat.ID, fmt.Sprintf("Выбран хайк: %s", hike.TitleRu))
-	msg.ReplyMarkup = hikeUI.SelectedHikeActionsKeyboard(hike.IsPublished)
+		switch state {
+		case fsm.StateConfirm:
+			return h.sendCreatePreview(ctx, m.Chat.ID, m.From.ID)
+		case fsm.StateCreatePublishAt, fsm.StateCreateUnpublishAt:
+			return h.sendScheduleStep(m.Chat.ID, state, createStepPrompt(state))
+		case fsm.StateCreateTitleEN, fsm.StateCreatePreviewEN, fsm.StateCreateDescEN:
+			return h.sendTranslationStep(m.Chat.ID, createStepPrompt(state))
+		case fsm.StateCreateDifficulty:
+			return h.sendDifficultyStep(ctx, m.Chat.ID, m.From.ID)
+		case fsm.StateCreateTags:
+			return h.sendTagsStep(ctx, m.Chat.ID, m.From.ID, createStepPrompt(state))
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(state))
 
-	_, err = h.bot.Send(msg)
-	return err
+	case "🗑 Начать заново":
+		h.fsm.DropDraft(ctx, m.From.ID)
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateTitleRU, nil); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreateTitleRU))
+
+	default:
+		msg := tgbot.NewMessage(m.Chat.ID, "Выберите действие кнопкой ниже.")
+		msg.ReplyMarkup = hikeUI.ContinueDraftKeyboard()
+
+		_, err := h.bot.Send(msg)
+		return err
+	}
+}
+
+// createStepPrompt is the question asked when the creation scenario enters the state.
+func createStepPrompt(state fsm.State) string {
+	switch state {
+	case fsm.StateCreateTitleRU:
+		return "Введите название RU:"
+	case fsm.StateCreateTitleEN:
+		return "Введите название EN — его увидят клиенты с английским языком. " + translationHint
+	case fsm.StateCreatePreviewRU:
+		return "Введите превью RU (1024 символа):"
+	case fsm.StateCreatePreviewEN:
+		return "Введите превью EN (1024 символа). " + translationHint
+	case fsm.StateCreateDescRU:
+		return "Введите описание RU:"
+	case fsm.StateCreateDescEN:
+		return "Введите описание EN. " + translationHint
+	case fsm.StateCreatePrice:
+		return "Введите цену в лари (например: 120):"
+	case fsm.StateCreateDistanceKm:
+		return "Введите длину маршрута в км (например: 8.5):"
+	case fsm.StateCreateElevationGain:
+		return "Введите набор высоты в метрах (например: 650):"
+	case fsm.StateCreateTags:
+		return "Отметьте теги хайка кнопками — повторное нажатие снимает тег. Когда закончите, нажмите «" + hikeUI.TagsDoneButton + "»."
+	case fsm.StateCreateMaxParticipants:
+		return "Введите максимальное количество участников (0 — без ограничений):"
+	case fsm.StateCreateDates:
+		return "Введите даты начала и завершения хайка (примеры: 10, 10 12, 10-12, 31 3, 03.02-04.02, 15.12 16.12)."
+	case fsm.StateCreatePhoto:
+		return "Загрузите фото:"
+	case fsm.StateCreatePublishAt:
+		return "Когда опубликовать хайк? " + scheduleFormatHint + "\nИли опубликуйте его вручную позже."
+	case fsm.StateCreateUnpublishAt:
+		return "Когда закрыть запись? Хайк пропадёт из списка у клиентов в это время. " + scheduleFormatHint + "\nИли оставьте запись открытой до окончания хайка."
+	default:
+		return ""
+	}
 }
 
 func (h *HikeHandler) HandlePublishHike(ctx context.Context, m *tgbot.Message) error {
 	txt := strings.TrimSpace(m.Text)
+	key, _ := i18n.KeyOf(txt)
 
-	switch h.fsm.State(m.From.ID) {
+	switch h.fsm.State(ctx, m.From.ID) {
 	case fsm.StateSelectedHikeAction:
-		data := h.fsm.Data(m.From.ID)
+		data := h.fsm.Data(ctx, m.From.ID)
 		title := data["selected_hike_title"]
 		isPublished, _ := strconv.ParseBool(data["selected_hike_is_published"])
 
-		switch txt {
-		case "📢 Опубликовать хайк":
-			if isPublished {
-				_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Этот хайк уже опубликован."))
-				return err
-			}
+		switch key {
+		case i18n.BtnAdminPublishHike:
+			return h.askPublishHike(ctx, m, title, isPublished)
 
-			h.fsm.Set(m.From.ID, fsm.StateConfirmPublishHike)
+		case i18n.BtnAdminHideHike:
+			return h.askHideHike(ctx, m, title, isPublished)
 
-			msg := tgbot.NewMessage(
-				m.Chat.ID,
-				fmt.Sprintf("Вы действительно хотите опубликовать хайк?\n\n%s", title),
-			)
-			msg.ReplyMarkup = hikeUI.PublishConfirmKeyboard()
+		case i18n.BtnAdminEditHike:
+			return h.StartEditHike(ctx, m)
 
-			_, err := h.bot.Send(msg)
-			return err
+		case i18n.BtnAdminCloneHike:
+			return h.StartCloneHike(ctx, m)
+
+		case i18n.BtnAdminHikeCard:
+			return h.sendHikeCard(ctx, m)
 
-		case "🙈 Скрыть хайк":
-			if !isPublished {
-				_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Этот хайк уже скрыт."))
+		case i18n.BtnAdminCancelHike:
+			if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCancelHikeReason, nil); err != nil {
 				return err
 			}
 
-			h.fsm.Set(m.From.ID, fsm.StateConfirmHideHike)
-
 			msg := tgbot.NewMessage(
 				m.Chat.ID,
-				fmt.Sprintf("Вы действительно хотите скрыть хайк?\n\n%s", title),
+				fmt.Sprintf("Укажите причину отмены хайка — её увидят все записавшиеся клиенты.\n\n%s", title),
 			)
-			msg.ReplyMarkup = hikeUI.HideConfirmKeyboard()
+			msg.ReplyMarkup = hikeUI.CancelHikeReasonKeyboard()
 
 			_, err := h.bot.Send(msg)
 			return err
 
-		case "🧾 Карточка хайка":
-			_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Карточка хайка пока в разработке."))
-			return err
+		case i18n.BtnAdminHikeRoster:
+			return h.sendRoster(ctx, m)
 
-		case "⬅️ Назад":
-			h.fsm.Set(m.From.ID, fsm.StateSelectHikeID)
-			_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Введите ID хайка."))
-			return err
+		case i18n.BtnAdminHikeReviews:
+			return h.sendFeedback(ctx, m)
+
+		case i18n.BtnBack:
+			h.fsm.Reset(ctx, m.From.ID)
+			if err := h.ShowMenu(ctx, m); err != nil {
+				return err
+			}
+			return h.ListHikes(ctx, m)
 
 		default:
 			msg := tgbot.NewMessage(m.Chat.ID, "Выберите действие с помощью кнопок ниже.")
@@ -171,12 +276,12 @@ func (h *HikeHandler) HandlePublishHike(ctx context.Context, m *tgbot.Message) e
 		}
 
 	case fsm.StateConfirmPublishHike:
-		switch txt {
-		case "✅ Да, опубликовать":
+		switch key {
+		case i18n.BtnAdminConfirmPublish:
 			return h.confirmPublishHike(ctx, m)
 
-		case "❌ Отмена":
-			return h.backToSelectedHikeActions(m)
+		case i18n.BtnAdminCancel:
+			return h.backToSelectedHikeActions(ctx, m)
 
 		default:
 			msg := tgbot.NewMessage(m.Chat.ID, "Подтвердите публикацию или отмените действие.")
@@ -187,33 +292,82 @@ func (h *HikeHandler) HandlePublishHike(ctx context.Context, m *tgbot.Message) e
 		}
 
 	case fsm.StateConfirmHideHike:
-		switch txt {
-		case "✅ Да, скрыть":
+		switch key {
+		case i18n.BtnAdminConfirmHide:
 			return h.confirmHideHike(ctx, m)
 
-		case "❌ Отмена":
-			return h.backToSelectedHikeActions(m)
+		case i18n.BtnAdminCancel:
+			return h.backToSelectedHikeActions(ctx, m)
 
 		default:
 			msg := tgbot.NewMessage(m.Chat.ID, "Подтвердите скрытие или отмените действие.")
 			msg.ReplyMarkup = hikeUI.HideConfirmKeyboard()
 
+			_, err := h.bot.Send(msg)
+			return err
+		}
+
+	case fsm.StateCancelHikeReason:
+		if key == i18n.BtnAdminCancel {
+			return h.backToSelectedHikeActions(ctx, m)
+		}
+		if txt == "" {
+			msg := tgbot.NewMessage(m.Chat.ID, "Напишите причину отмены текстом или выберите её кнопкой.")
+			msg.ReplyMarkup = hikeUI.CancelHikeReasonKeyboard()
+
+			_, err := h.bot.Send(msg)
+			return err
+		}
+
+		reason := txt
+		if key == i18n.BtnAdminCancelReasonWeather {
+			reason = strings.TrimSpace(strings.TrimPrefix(txt, "🌧"))
+		}
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateConfirmCancelHike, map[string]string{"cancel_reason": reason}); err != nil {
+			return err
+		}
+
+		msg := tgbot.NewMessage(
+			m.Chat.ID,
+			fmt.Sprintf(
+				"Отменить хайк? Он будет скрыт, все активные заявки отменены, клиенты получат уведомление.\n\n%s\nПричина: %s",
+				h.fsm.Data(ctx, m.From.ID)["selected_hike_title"],
+				reason,
+			),
+		)
+		msg.ReplyMarkup = hikeUI.CancelHikeConfirmKeyboard()
+
+		_, err := h.bot.Send(msg)
+		return err
+
+	case fsm.StateConfirmCancelHike:
+		switch key {
+		case i18n.BtnAdminConfirmCancelHike:
+			return h.confirmCancelHike(ctx, m)
+
+		case i18n.BtnAdminCancel:
+			return h.backToSelectedHikeActions(ctx, m)
+
+		default:
+			msg := tgbot.NewMessage(m.Chat.ID, "Подтвердите отмену хайка или вернитесь назад.")
+			msg.ReplyMarkup = hikeUI.CancelHikeConfirmKeyboard()
+
 			_, err := h.bot.Send(msg)
 			return err
 		}
 	}
 
-	h.fsm.Reset(m.From.ID)
+	h.fsm.Reset(ctx, m.From.ID)
 	_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Неизвестное состояние. Сбросил сценарий."))
 	return err
 }
 
 func (h *HikeHandler) confirmPublishHike(ctx context.Context, m *tgbot.Message) error {
-	data := h.fsm.Data(m.From.ID)
+	data := h.fsm.Data(ctx, m.From.ID)
 
 	hikeID, err := strconv.Atoi(data["selected_hike_id"])
 	if err != nil {
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Некорректный ID хайка. Состояние сброшено."))
 		if sendErr != nil {
 			return sendErr
@@ -223,7 +377,7 @@ func (h *HikeHandler) confirmPublishHike(ctx context.Context, m *tgbot.Message)
 
 	hike, err := h.service.GetHike(ctx, int32(hikeID))
 	if err != nil {
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, fmt.Sprintf("Хайк с ID %d не найден.", hikeID)))
 		if sendErr != nil {
 			return sendErr
@@ -232,7 +386,7 @@ func (h *HikeHandler) confirmPublishHike(ctx context.Context, m *tgbot.Message)
 	}
 
 	if hike.IsPublished {
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 
 		msg := tgbot.NewMessage(m.Chat.ID, "Этот хайк уже опубликован.")
 		msg.ReplyMarkup = hikeUI.HikeMenu()
@@ -249,7 +403,7 @@ func (h *HikeHandler) confirmPublishHike(ctx context.Context, m *tgbot.Message)
 		return err
 	}
 
-	h.fsm.Reset(m.From.ID)
+	h.fsm.Reset(ctx, m.From.ID)
 
 	msg := tgbot.NewMessage(m.Chat.ID, "Хайк успешно опубликован ✅")
 	msg.ReplyMarkup = hikeUI.HikeMenu()
@@ -259,11 +413,11 @@ func (h *HikeHandler) confirmPublishHike(ctx context.Context, m *tgbot.Message)
 }
 
 func (h *HikeHandler) confirmHideHike(ctx context.Context, m *tgbot.Message) error {
-	data := h.fsm.Data(m.From.ID)
+	data := h.fsm.Data(ctx, m.From.ID)
 
 	hikeID, err := strconv.Atoi(data["selected_hike_id"])
 	if err != nil {
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Некорректный ID хайка. Состояние сброшено."))
 		if sendErr != nil {
 			return sendErr
@@ -273,7 +427,7 @@ func (h *HikeHandler) confirmHideHike(ctx context.Context, m *tgbot.Message) err
 
 	hike, err := h.service.GetHike(ctx, int32(hikeID))
 	if err != nil {
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, fmt.Sprintf("Хайк с ID %d не найден.", hikeID)))
 		if sendErr != nil {
 			return sendErr
@@ -282,7 +436,7 @@ func (h *HikeHandler) confirmHideHike(ctx context.Context, m *tgbot.Message) err
 	}
 
 	if !hike.IsPublished {
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 
 		msg := tgbot.NewMessage(m.Chat.ID, "Этот хайк уже скрыт.")
 		msg.ReplyMarkup = hikeUI.HikeMenu()
@@ -299,7 +453,7 @@ func (h *HikeHandler) confirmHideHike(ctx context.Context, m *tgbot.Message) err
 		return err
 	}
 
-	h.fsm.Reset(m.From.ID)
+	h.fsm.Reset(ctx, m.From.ID)
 
 	msg := tgbot.NewMessage(m.Chat.ID, "Хайк успешно скрыт 🙈")
 	msg.ReplyMarkup = hikeUI.HikeMenu()
@@ -308,11 +462,192 @@ func (h *HikeHandler) confirmHideHike(ctx context.Context, m *tgbot.Message) err
 	return err
 }
 
-func (h *HikeHandler) backToSelectedHikeActions(m *tgbot.Message) error {
-	data := h.fsm.Data(m.From.ID)
+// sendRoster exports all bookings of the selected hike as CSV and as a
+// printable HTML page.
+func (h *HikeHandler) sendRoster(ctx context.Context, m *tgbot.Message) error {
+	hikeID, err := strconv.Atoi(h.fsm.Data(ctx, m.From.ID)["selected_hike_id"])
+	if err != nil {
+		h.fsm.Reset(ctx, m.From.ID)
+		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Некорректный ID хайка. Состояние сброшено."))
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+
+	hike, err := h.service.GetHike(ctx, int32(hikeID))
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, fmt.Sprintf("Хайк с ID %d не найден.", hikeID)))
+		return err
+	}
+
+	bookings, err := h.bookingService.ListHikeRoster(ctx, hike.ID)
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Не удалось загрузить список участников."))
+		return err
+	}
+
+	if len(bookings) == 0 {
+		_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "На этот хайк пока нет заявок."))
+		return err
+	}
+
+	startsAt := hike.StartsAt.In(h.loc)
+
+	csvData, err := bookingUI.RosterCSV(bookings)
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Не удалось сформировать список участников."))
+		return logger.WrapError(err)
+	}
+
+	csvDoc := tgbot.NewDocument(m.Chat.ID, tgbot.FileBytes{
+		Name:  bookingUI.RosterFileName(hike.ID, startsAt, "csv"),
+		Bytes: csvData,
+	})
+	csvDoc.Caption = bookingUI.RosterCaption(hike.TitleRu, startsAt, bookings)
+	csvDoc.ParseMode = tgbot.ModeHTML
+
+	if _, err := h.bot.Send(csvDoc); err != nil {
+		return logger.WrapError(err)
+	}
+
+	htmlDoc := tgbot.NewDocument(m.Chat.ID, tgbot.FileBytes{
+		Name:  bookingUI.RosterFileName(hike.ID, startsAt, "html"),
+		Bytes: bookingUI.RosterHTML(hike.TitleRu, startsAt, bookings),
+	})
+	htmlDoc.Caption = "🖨 Версия для печати"
+
+	_, err = h.bot.Send(htmlDoc)
+	return logger.WrapError(err)
+}
+
+func (h *HikeHandler) sendFeedback(ctx context.Context, m *tgbot.Message) error {
+	data := h.fsm.Data(ctx, m.From.ID)
+
+	hikeID, err := strconv.Atoi(data["selected_hike_id"])
+	if err != nil {
+		h.fsm.Reset(ctx, m.From.ID)
+		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Некорректный ID хайка. Состояние сброшено."))
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+
+	feedback, err := h.service.GetFeedback(ctx, int32(hikeID))
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Не удалось загрузить отзывы."))
+		return err
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, hikeUI.FeedbackReport(data["selected_hike_title"], feedback, h.loc))
+	msg.ParseMode = tgbot.ModeHTML
+
+	_, err = h.bot.Send(msg)
+	return err
+}
+
+// confirmCancelHike hides the hike and cancels all its active bookings.
+// The hike is canceled even if some clients couldn't be notified.
+func (h *HikeHandler) confirmCancelHike(ctx context.Context, m *tgbot.Message) error {
+	data := h.fsm.Data(ctx, m.From.ID)
+
+	hikeID, err := strconv.Atoi(data["selected_hike_id"])
+	if err != nil {
+		h.fsm.Reset(ctx, m.From.ID)
+		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Некорректный ID хайка. Состояние сброшено."))
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+
+	canceled, err := h.bookingService.CancelHike(ctx, int32(hikeID), data["cancel_reason"])
+
+	var text string
+	switch {
+	case err == nil:
+		text = fmt.Sprintf("Хайк отменён 🚫 Отменено заявок: %d, клиенты уведомлены.", canceled)
+
+	case errors.Is(err, bookingService.ErrClientNotification):
+		text = fmt.Sprintf("Хайк отменён 🚫 Отменено заявок: %d, но не всех клиентов удалось уведомить.", canceled)
+
+	default:
+		msg := tgbot.NewMessage(m.Chat.ID, "Не удалось отменить хайк.")
+		msg.ReplyMarkup = hikeUI.CancelHikeConfirmKeyboard()
+
+		_, _ = h.bot.Send(msg)
+		return err
+	}
+
+	h.fsm.Reset(ctx, m.From.ID)
+
+	msg := tgbot.NewMessage(m.Chat.ID, text)
+	msg.ReplyMarkup = hikeUI.HikeMenu()
+
+	if _, sendErr := h.bot.Send(msg); sendErr != nil && err == nil {
+		err = sendErr
+	}
+
+	return err
+}
+
+// selectHike remembers the hike the admin works with and waits for an action.
+func (h *HikeHandler) selectHike(ctx context.Context, userID int64, hike service.Hike) error {
+	return h.fsm.Set(ctx, userID, fsm.StateSelectedHikeAction, map[string]string{
+		"selected_hike_id":           fmt.Sprintf("%d", hike.ID),
+		"selected_hike_title":        hike.TitleRu,
+		"selected_hike_is_published": strconv.FormatBool(hike.IsPublished),
+	})
+}
+
+func (h *HikeHandler) askPublishHike(ctx context.Context, m *tgbot.Message, title string, isPublished bool) error {
+	if isPublished {
+		_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Этот хайк уже опубликован."))
+		return err
+	}
+
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateConfirmPublishHike, nil); err != nil {
+		return err
+	}
+
+	msg := tgbot.NewMessage(
+		m.Chat.ID,
+		fmt.Sprintf("Вы действительно хотите опубликовать хайк?\n\n%s", title),
+	)
+	msg.ReplyMarkup = hikeUI.PublishConfirmKeyboard()
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *HikeHandler) askHideHike(ctx context.Context, m *tgbot.Message, title string, isPublished bool) error {
+	if !isPublished {
+		_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Этот хайк уже скрыт."))
+		return err
+	}
+
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateConfirmHideHike, nil); err != nil {
+		return err
+	}
+
+	msg := tgbot.NewMessage(
+		m.Chat.ID,
+		fmt.Sprintf("Вы действительно хотите скрыть хайк?\n\n%s", title),
+	)
+	msg.ReplyMarkup = hikeUI.HideConfirmKeyboard()
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+func (h *HikeHandler) backToSelectedHikeActions(ctx context.Context, m *tgbot.Message) error {
+	data := h.fsm.Data(ctx, m.From.ID)
 	isPublished, _ := strconv.ParseBool(data["selected_hike_is_published"])
 
-	h.fsm.Set(m.From.ID, fsm.StateSelectedHikeAction)
+	if err := h.fsm.Set(ctx, m.From.ID, fsm.StateSelectedHikeAction, nil); err != nil {
+		return err
+	}
 
 	msg := tgbot.NewMessage(m.Chat.ID, "Действие отменено. Выберите действие.")
 	msg.ReplyMarkup = hikeUI.SelectedHikeActionsKeyboard(isPublished)
@@ -322,11 +657,18 @@ func (h *HikeHandler) backToSelectedHikeActions(m *tgbot.Message) error {
 }
 
 func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) error {
-	switch h.fsm.State(m.From.ID) {
+	switch h.fsm.State(ctx, m.From.ID) {
 	case fsm.StateCreateTitleRU:
-		h.fsm.Put(m.From.ID, "title_ru", strings.TrimSpace(m.Text))
-		h.fsm.Set(m.From.ID, fsm.StateCreatePreviewRU)
-		return h.sendCreateStep(m.Chat.ID, "Введите превью RU (1024 символа):")
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateTitleEN, map[string]string{"title_ru": strings.TrimSpace(m.Text)}); err != nil {
+			return err
+		}
+		return h.sendTranslationStep(m.Chat.ID, createStepPrompt(fsm.StateCreateTitleEN))
+
+	case fsm.StateCreateTitleEN:
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreatePreviewRU, map[string]string{"title_en": translationFromText(m.Text)}); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreatePreviewRU))
 
 	case fsm.StateCreatePreviewRU:
 		preview := strings.TrimSpace(m.Text)
@@ -347,15 +689,41 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 			return nil
 		}
 
-		h.fsm.Put(m.From.ID, "preview_ru", preview)
-		h.fsm.Set(m.From.ID, fsm.StateCreateDescRU)
-		return h.sendCreateStep(m.Chat.ID, "Введите описание RU:")
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreatePreviewEN, map[string]string{"preview_ru": preview}); err != nil {
+			return err
+		}
+		return h.sendTranslationStep(m.Chat.ID, createStepPrompt(fsm.StateCreatePreviewEN))
+
+	case fsm.StateCreatePreviewEN:
+		preview := translationFromText(m.Text)
+
+		if count := utf8.RuneCountInString(preview); count > 1024 {
+			_ = h.sendTranslationStep(
+				m.Chat.ID,
+				fmt.Sprintf(
+					"Превью слишком длинное: %d символов из 1024 допустимых. Сократите текст.",
+					count,
+				),
+			)
+			return nil
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateDescRU, map[string]string{"preview_en": preview}); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreateDescRU))
 
 	case fsm.StateCreateDescRU:
-		h.fsm.Put(m.From.ID, "description_ru", strings.TrimSpace(m.Text))
-		h.fsm.Set(m.From.ID, fsm.StateCreatePrice)
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateDescEN, map[string]string{"description_ru": strings.TrimSpace(m.Text)}); err != nil {
+			return err
+		}
+		return h.sendTranslationStep(m.Chat.ID, createStepPrompt(fsm.StateCreateDescEN))
 
-		return h.sendCreateStep(m.Chat.ID, "Введите цену в лари (например: 120):")
+	case fsm.StateCreateDescEN:
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreatePrice, map[string]string{"description_en": translationFromText(m.Text)}); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreatePrice))
 
 	case fsm.StateCreatePrice:
 		txt := strings.TrimSpace(m.Text)
@@ -366,9 +734,10 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 			return nil
 		}
 
-		h.fsm.Put(m.From.ID, "price_gel", strconv.Itoa(price))
-		h.fsm.Set(m.From.ID, fsm.StateCreateDistanceKm)
-		return h.sendCreateStep(m.Chat.ID, "Введите длину маршрута в км (например: 8.5):")
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateDistanceKm, map[string]string{"price_gel": strconv.Itoa(price)}); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreateDistanceKm))
 
 	case fsm.StateCreateDistanceKm:
 		txt := strings.TrimSpace(strings.ReplaceAll(m.Text, ",", "."))
@@ -379,9 +748,10 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 			return nil
 		}
 
-		h.fsm.Put(m.From.ID, "distance_km", strconv.FormatFloat(distance, 'f', 2, 64))
-		h.fsm.Set(m.From.ID, fsm.StateCreateElevationGain)
-		return h.sendCreateStep(m.Chat.ID, "Введите набор высоты в метрах (например: 650):")
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateElevationGain, map[string]string{"distance_km": strconv.FormatFloat(distance, 'f', 2, 64)}); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreateElevationGain))
 
 	case fsm.StateCreateElevationGain:
 		txt := strings.TrimSpace(m.Text)
@@ -392,11 +762,60 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 			return nil
 		}
 
-		h.fsm.Put(m.From.ID, "elevation_gain_m", strconv.Itoa(elevationGain))
-		h.fsm.Set(m.From.ID, fsm.StateCreateDates)
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateDifficulty, map[string]string{"elevation_gain_m": strconv.Itoa(elevationGain)}); err != nil {
+			return err
+		}
+		return h.sendDifficultyStep(ctx, m.Chat.ID, m.From.ID)
+
+	case fsm.StateCreateDifficulty:
+		difficulty, ok := hikeUI.DifficultyByText(m.Text)
+		if !ok {
+			return h.sendDifficultyStep(ctx, m.Chat.ID, m.From.ID)
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateTags, map[string]string{"difficulty": string(difficulty)}); err != nil {
+			return err
+		}
+		return h.sendTagsStep(ctx, m.Chat.ID, m.From.ID, createStepPrompt(fsm.StateCreateTags))
 
-		examples := "Введите даты начала и завершения хайка (примеры: 10, 10 12, 10-12, 31 3, 03.02-04.02, 15.12 16.12)."
-		return h.sendCreateStep(m.Chat.ID, examples)
+	case fsm.StateCreateTags:
+		txt := strings.TrimSpace(m.Text)
+
+		if txt == hikeUI.TagsDoneButton {
+			if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateMaxParticipants, nil); err != nil {
+				return err
+			}
+			return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreateMaxParticipants))
+		}
+
+		toggled, ok := hikeUI.TagsByText(txt)
+		if !ok {
+			return h.sendTagsStep(ctx, m.Chat.ID, m.From.ID, "Выберите теги кнопками ниже. "+createStepPrompt(fsm.StateCreateTags))
+		}
+
+		tags := tagsFromData(h.fsm.Data(ctx, m.From.ID)["tags"])
+		for _, t := range toggled {
+			tags = toggleTag(tags, t)
+		}
+		if err := h.fsm.Put(ctx, m.From.ID, map[string]string{"tags": tagsToData(tags)}); err != nil {
+			return err
+		}
+
+		return h.sendTagsStep(ctx, m.Chat.ID, m.From.ID, "Теги: "+hikeUI.TagsLabel(tags))
+
+	case fsm.StateCreateMaxParticipants:
+		txt := strings.TrimSpace(m.Text)
+
+		maxParticipants, err := strconv.Atoi(txt)
+		if err != nil || maxParticipants < 0 {
+			_ = h.sendCreateStep(m.Chat.ID, "Введите количество участников целым числом. Например: 12 (0 — без ограничений)")
+			return nil
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateDates, map[string]string{"max_participants": strconv.Itoa(maxParticipants)}); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreateDates))
 
 	case fsm.StateCreateDates:
 		loc := h.loc
@@ -406,11 +825,13 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 			return nil
 		}
 
-		h.fsm.Put(m.From.ID, "starts_at", start.Format("02.01.2006 15:04"))
-		h.fsm.Put(m.From.ID, "ends_at", end.Format("02.01.2006 15:04"))
-		h.fsm.Set(m.From.ID, fsm.StateCreatePhoto)
-
-		return h.sendCreateStep(m.Chat.ID, "Загрузите фото:")
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreatePhoto, map[string]string{
+			"starts_at": start.Format("02.01.2006 15:04"),
+			"ends_at":   end.Format("02.01.2006 15:04"),
+		}); err != nil {
+			return err
+		}
+		return h.sendCreateStep(m.Chat.ID, createStepPrompt(fsm.StateCreatePhoto))
 
 	case fsm.StateCreatePhoto:
 		if len(m.Photo) == 0 {
@@ -419,9 +840,8 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 		}
 
 		photo := m.Photo[len(m.Photo)-1]
-		h.fsm.Put(m.From.ID, "photo_file_id", photo.FileID)
 
-		clientCaptionLen := countClientCaption(h.fsm.Data(m.From.ID))
+		clientCaptionLen := countClientCaption(h.fsm.Data(ctx, m.From.ID))
 		if clientCaptionLen > 1024 {
 			_ = h.sendCreateStep(
 				m.Chat.ID,
@@ -432,37 +852,71 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 			)
 			return nil
 		}
-		h.fsm.Set(m.From.ID, fsm.StateConfirm)
-
-		// TODO: вынести формирование Caption в общий пакет
-		preview := fmt.Sprintf(
-			"📋 <b>Проверьте данные хайка</b>\n\n"+
-				"🏔 Название: %s\n"+
-				"🔎 Превью: %s\n"+
-				"📝 Описание: %s\n"+
-				"💰 Цена: %s GEL\n"+
-				"📏 Длина: %s км\n"+
-				"⛰ Набор высоты: %s м\n"+
-				"🗓 Даты: %s → %s\n"+
-				"📷 Фото: добавлено\n\n"+
-				"📐 Общий Telegram caption: %d / 1024\n\n"+
-				"Выберите действие ниже:",
-			h.fsm.Data(m.From.ID)["title_ru"],
-			h.fsm.Data(m.From.ID)["preview_ru"],
-			h.fsm.Data(m.From.ID)["description_ru"],
-			h.fsm.Data(m.From.ID)["price_gel"],
-			h.fsm.Data(m.From.ID)["distance_km"],
-			h.fsm.Data(m.From.ID)["elevation_gain_m"],
-			h.fsm.Data(m.From.ID)["starts_at"],
-			h.fsm.Data(m.From.ID)["ends_at"],
-			clientCaptionLen,
-		)
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreatePublishAt, map[string]string{"photo_file_id": photo.FileID}); err != nil {
+			return err
+		}
+		return h.sendScheduleStep(m.Chat.ID, fsm.StateCreatePublishAt, createStepPrompt(fsm.StateCreatePublishAt))
 
-		msg := tgbot.NewMessage(m.Chat.ID, preview)
-		msg.ReplyMarkup = hikeUI.HikeConfirmMenu()
-		msg.ParseMode = tgbot.ModeHTML
-		_, err := h.bot.Send(msg)
-		return err
+	case fsm.StateCreatePublishAt:
+		txt := strings.TrimSpace(m.Text)
+
+		publishAt := ""
+		if txt != "✋ Опубликую вручную" {
+			now := time.Now().In(h.loc)
+
+			t, err := parser.ParseDateTime(txt, now, h.loc)
+			if err != nil {
+				return h.sendScheduleStep(m.Chat.ID, fsm.StateCreatePublishAt, "Не получилось распознать дату и время. "+scheduleFormatHint)
+			}
+
+			endsAt, err := time.ParseInLocation("02.01.2006 15:04", h.fsm.Data(ctx, m.From.ID)["ends_at"], h.loc)
+			if err != nil {
+				return logger.WrapError(err)
+			}
+
+			if problem := checkSchedule(&t, nil, endsAt, now); problem != "" {
+				return h.sendScheduleStep(m.Chat.ID, fsm.StateCreatePublishAt, problem)
+			}
+			publishAt = t.Format("02.01.2006 15:04")
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateCreateUnpublishAt, map[string]string{"publish_at": publishAt}); err != nil {
+			return err
+		}
+		return h.sendScheduleStep(m.Chat.ID, fsm.StateCreateUnpublishAt, createStepPrompt(fsm.StateCreateUnpublishAt))
+
+	case fsm.StateCreateUnpublishAt:
+		txt := strings.TrimSpace(m.Text)
+
+		unpublishAt := ""
+		if txt != "♾ Не закрывать запись" {
+			now := time.Now().In(h.loc)
+			data := h.fsm.Data(ctx, m.From.ID)
+
+			t, err := parser.ParseDateTime(txt, now, h.loc)
+			if err != nil {
+				return h.sendScheduleStep(m.Chat.ID, fsm.StateCreateUnpublishAt, "Не получилось распознать дату и время. "+scheduleFormatHint)
+			}
+
+			publishAt, err := scheduleFromData(data["publish_at"], h.loc)
+			if err != nil {
+				return err
+			}
+			endsAt, err := time.ParseInLocation("02.01.2006 15:04", data["ends_at"], h.loc)
+			if err != nil {
+				return logger.WrapError(err)
+			}
+
+			if problem := checkSchedule(publishAt, &t, endsAt, now); problem != "" {
+				return h.sendScheduleStep(m.Chat.ID, fsm.StateCreateUnpublishAt, problem)
+			}
+			unpublishAt = t.Format("02.01.2006 15:04")
+		}
+
+		if err := h.fsm.Set(ctx, m.From.ID, fsm.StateConfirm, map[string]string{"unpublish_at": unpublishAt}); err != nil {
+			return err
+		}
+		return h.sendCreatePreview(ctx, m.Chat.ID, m.From.ID)
 
 	case fsm.StateConfirm:
 		txt := strings.TrimSpace(strings.ToLower(m.Text))
@@ -474,16 +928,21 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 				return err
 			}
 
-			h.fsm.Reset(m.From.ID)
+			text := "Хайк создан!"
+			if publishAt := h.fsm.Data(ctx, m.From.ID)["publish_at"]; publishAt != "" {
+				text = fmt.Sprintf("Хайк создан! Он будет опубликован %s.", publishAt)
+			}
+
+			h.fsm.Reset(ctx, m.From.ID)
 
-			msg := tgbot.NewMessage(m.Chat.ID, "Хайк создан!")
+			msg := tgbot.NewMessage(m.Chat.ID, text)
 			msg.ReplyMarkup = hikeUI.HikeMenu()
 
 			_, err := h.bot.Send(msg)
 			return err
 
 		case "❌ отмена":
-			h.fsm.Reset(m.From.ID)
+			h.fsm.Reset(ctx, m.From.ID)
 
 			msg := tgbot.NewMessage(m.Chat.ID, "Создание отменено.")
 			msg.ReplyMarkup = hikeUI.HikeMenu()
@@ -499,32 +958,116 @@ func (h *HikeHandler) HandleCreateHike(ctx context.Context, m *tgbot.Message) er
 		}
 
 	default:
-		h.fsm.Reset(m.From.ID)
+		h.fsm.Reset(ctx, m.From.ID)
 		_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Сбросил состояние."))
 		return err
 	}
 }
 
+func (h *HikeHandler) sendCreatePreview(ctx context.Context, chatID, userID int64) error {
+	data := h.fsm.Data(ctx, userID)
+
+	// TODO: вынести формирование Caption в общий пакет
+	preview := fmt.Sprintf(
+		"📋 <b>Проверьте данные хайка</b>\n\n"+
+			"🏔 Название: %s\n"+
+			"🔎 Превью: %s\n"+
+			"📝 Описание: %s\n"+
+			"🇬🇧 Название EN: %s\n"+
+			"🇬🇧 Превью EN: %s\n"+
+			"🇬🇧 Описание EN: %s\n"+
+			"💰 Цена: %s GEL\n"+
+			"📏 Длина: %s км\n"+
+			"⛰ Набор высоты: %s м\n"+
+			"🎚 Сложность: %s\n"+
+			"🏷 Теги: %s\n"+
+			"👥 Мест: %s\n"+
+			"🗓 Даты: %s → %s\n"+
+			"📷 Фото: добавлено\n"+
+			"📢 Публикация: %s\n"+
+			"🔒 Запись до: %s\n\n"+
+			"📐 Общий Telegram caption: %d / 1024\n\n"+
+			"Выберите действие ниже:",
+		data["title_ru"],
+		data["preview_ru"],
+		data["description_ru"],
+		orDefault(data["title_en"], "—"),
+		orDefault(data["preview_en"], "—"),
+		orDefault(data["description_en"], "—"),
+		data["price_gel"],
+		data["distance_km"],
+		data["elevation_gain_m"],
+		hikeUI.DifficultyLabel(draftDifficulty(data)),
+		hikeUI.TagsLabel(tagsFromData(data["tags"])),
+		formatMaxParticipants(data["max_participants"]),
+		data["starts_at"],
+		data["ends_at"],
+		orDefault(data["publish_at"], "вручную"),
+		orDefault(data["unpublish_at"], "до окончания хайка"),
+		countClientCaption(data),
+	)
+
+	msg := tgbot.NewMessage(chatID, preview)
+	msg.ReplyMarkup = hikeUI.HikeConfirmMenu()
+	msg.ParseMode = tgbot.ModeHTML
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+// countClientCaption returns the length of the longest client caption of the
+// hike. Clients with English see the EN title and preview where they are set.
 func countClientCaption(data map[string]string) int {
+	ru := clientCaptionLength(data["title_ru"], data["preview_ru"], data, "ru")
+	en := clientCaptionLength(
+		orDefault(data["title_en"], data["title_ru"]),
+		orDefault(data["preview_en"], data["preview_ru"]),
+		data,
+		"en",
+	)
+	return max(ru, en)
+}
+
+func clientCaptionLength(title, preview string, data map[string]string, lang string) int {
+	marks := []string{trail.DifficultyLabel(draftDifficulty(data), lang)}
+	for _, t := range tagsFromData(data["tags"]) {
+		marks = append(marks, trail.TagLabel(t, lang))
+	}
+
 	return utf8.RuneCountInString(fmt.Sprintf(
 		"🏔 <b>%s</b>\n\n"+
 			"%s\n\n"+
 			"💰 %s GEL\n"+
 			"📏 %s км\n"+
 			"⛰ %s м\n"+
+			"%s\n"+
 			"🗓 %s → %s",
-		data["title_ru"],
-		data["preview_ru"],
+		title,
+		preview,
 		data["price_gel"],
 		data["distance_km"],
 		data["elevation_gain_m"],
+		strings.Join(marks, " • "),
 		data["starts_at"],
 		data["ends_at"],
 	))
 }
 
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func formatMaxParticipants(value string) string {
+	if value == "" || value == "0" {
+		return "без ограничений"
+	}
+	return value
+}
+
 func (h *HikeHandler) saveCreatedHike(ctx context.Context, userID int64) error {
-	data := h.fsm.Data(userID)
+	data := h.fsm.Data(ctx, userID)
 
 	startAt, err := time.ParseInLocation("02.01.2006 15:04", data["starts_at"], h.loc)
 	if err != nil {
@@ -551,7 +1094,12 @@ func (h *HikeHandler) saveCreatedHike(ctx context.Context, userID int64) error {
 		return logger.WrapError(err)
 	}
 
-	previewRu := strings.TrimSpace(h.fsm.Data(userID)["preview_ru"])
+	maxParticipants, err := strconv.Atoi(data["max_participants"])
+	if err != nil {
+		return logger.WrapError(err)
+	}
+
+	previewRu := strings.TrimSpace(h.fsm.Data(ctx, userID)["preview_ru"])
 	if previewRu == "" {
 		return logger.WrapError(errors.New("preview_ru is empty"))
 	}
@@ -560,16 +1108,34 @@ func (h *HikeHandler) saveCreatedHike(ctx context.Context, userID int64) error {
 		return logger.WrapError(errors.New("client caption is too long"))
 	}
 
+	publishAt, err := scheduleFromData(data["publish_at"], h.loc)
+	if err != nil {
+		return err
+	}
+
+	unpublishAt, err := scheduleFromData(data["unpublish_at"], h.loc)
+	if err != nil {
+		return err
+	}
+
 	hike := service.Hike{
-		TitleRu:        data["title_ru"],
-		PreviewRu:      previewRu,
-		DescriptionRu:  data["description_ru"],
-		PriceGel:       int32(priceGel),
-		DistanceKm:     distanceKm,
-		ElevationGainM: elevationGainM,
-		StartsAt:       startAt,
-		EndsAt:         endsAt,
-		PhotoFileID:    data["photo_file_id"],
+		TitleRu:         data["title_ru"],
+		TitleEn:         data["title_en"],
+		PreviewRu:       previewRu,
+		PreviewEn:       data["preview_en"],
+		DescriptionRu:   data["description_ru"],
+		DescriptionEn:   data["description_en"],
+		PriceGel:        int32(priceGel),
+		DistanceKm:      distanceKm,
+		ElevationGainM:  elevationGainM,
+		Difficulty:      draftDifficulty(data),
+		Tags:            tagsFromData(data["tags"]),
+		MaxParticipants: int32(maxParticipants),
+		StartsAt:        startAt,
+		EndsAt:          endsAt,
+		PhotoFileID:     data["photo_file_id"],
+		PublishAt:       publishAt,
+		UnpublishAt:     unpublishAt,
 	}
 
 	createdHikeID, err := h.service.CreateHike(ctx, hike)
@@ -633,7 +1199,7 @@ func (h *HikeHandler) saveImage(ctx context.Context, fileID string, hikeID int32
 }
 
 func (h *HikeHandler) ListHikes(ctx context.Context, m *tgbot.Message) error {
-	hikes, err := h.service.ListHikes(ctx, 1, 20)
+	page, err := h.service.ListHikes(ctx, service.FilterAll, 1, hikeUI.HikeListPageSize)
 	if err != nil {
 		_, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Не удалось загрузить список хайков."))
 		if sendErr != nil {
@@ -642,33 +1208,9 @@ func (h *HikeHandler) ListHikes(ctx context.Context, m *tgbot.Message) error {
 		return err
 	}
 
-	if len(hikes) == 0 {
-		_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Список хайков пуст."))
-		return err
-	}
-
-	var b strings.Builder
-	b.WriteString("🏔 Список хайков\n\n")
-
-	for _, hike := range hikes {
-		status := "📝"
-		if hike.IsPublished {
-			status = "✅"
-		}
+	msg := tgbot.NewMessage(m.Chat.ID, hikeUI.HikeListMessage(page))
+	msg.ReplyMarkup = hikeUI.HikeListKeyboard(page, h.loc)
 
-		line := fmt.Sprintf(
-			"#%d · %s · %s · %s\n\n",
-			hike.ID,
-			hike.TitleRu,
-			hike.StartsAt.In(h.loc).Format("02.01.2006"),
-			status,
-		)
-		b.WriteString(line)
-	}
-
-	h.fsm.Set(m.From.ID, fsm.StateSelectHikeID)
-	b.WriteString("\nОтправьте ID хайка, чтобы выбрать действие.")
-
-	_, err = h.bot.Send(tgbot.NewMessage(m.Chat.ID, b.String()))
+	_, err = h.bot.Send(msg)
 	return err
 }
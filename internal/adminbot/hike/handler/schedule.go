@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/fsm"
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const scheduleFormatHint = "Отправьте дату и время, например: 01.06 10:00"
+
+// sendScheduleStep asks for the publishing schedule of a new hike, the
+// keyboard lets the admin skip the step.
+func (h *HikeHandler) sendScheduleStep(chatID int64, state fsm.State, text string) error {
+	msg := tgbot.NewMessage(chatID, text)
+	msg.ReplyMarkup = hikeUI.PublishAtKeyboard()
+	if state == fsm.StateCreateUnpublishAt {
+		msg.ReplyMarkup = hikeUI.UnpublishAtKeyboard()
+	}
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+// checkSchedule returns what is wrong with the publishing schedule of a
+// hike, or an empty string if it is fine.
+func checkSchedule(publishAt, unpublishAt *time.Time, endsAt, now time.Time) string {
+	if publishAt != nil {
+		switch {
+		case !publishAt.After(now):
+			return "Время публикации должно быть в будущем."
+		case publishAt.After(endsAt):
+			return "Хайк должен быть опубликован до его окончания."
+		}
+	}
+
+	if unpublishAt == nil {
+		return ""
+	}
+
+	switch {
+	case !unpublishAt.After(now):
+		return "Время закрытия записи должно быть в будущем."
+	case publishAt != nil && !unpublishAt.After(*publishAt):
+		return "Запись должна закрываться позже публикации."
+	case unpublishAt.After(endsAt):
+		return "Запись должна закрываться до окончания хайка."
+	}
+
+	return ""
+}
+
+// scheduleFromData reads an optional moment stored in the FSM data.
+func scheduleFromData(value string, loc *time.Location) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation("02.01.2006 15:04", value, loc)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+	return &t, nil
+}
+
+func formatPublishAt(t *time.Time, loc *time.Location) string {
+	if t == nil {
+		return "вручную"
+	}
+	return t.In(loc).Format("02.01.2006 15:04")
+}
+
+func formatUnpublishAt(t *time.Time, loc *time.Location) string {
+	if t == nil {
+		return "до окончания хайка"
+	}
+	return t.In(loc).Format("02.01.2006 15:04")
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"strings"
+
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/hike"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const translationHint = "Без перевода клиенты увидят текст на русском."
+
+// skipTranslation is the keyboard button that leaves an EN field empty.
+const skipTranslation = "⏭ Без перевода"
+
+// sendTranslationStep asks for the EN version of a hike text, the keyboard
+// lets the admin skip the step.
+func (h *HikeHandler) sendTranslationStep(chatID int64, text string) error {
+	msg := tgbot.NewMessage(chatID, text)
+	msg.ReplyMarkup = hikeUI.TranslationKeyboard()
+
+	_, err := h.bot.Send(msg)
+	return err
+}
+
+// translationFromText returns the EN text sent by the admin, empty if the step was skipped.
+func translationFromText(text string) string {
+	text = strings.TrimSpace(text)
+	if text == skipTranslation {
+		return ""
+	}
+	return text
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/notify"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+)
+
+type notifier struct {
+	notify notify.Service
+}
+
+func New(n notify.Service) service.Notifier {
+	return &notifier{notify: n}
+}
+
+func (n *notifier) NotifyHikeChanged(ctx context.Context, client service.BookedClient, hike service.Hike, changes []service.Change) error {
+	data := notify.HikeUpdate{
+		HikeTitle: hike.TitleIn(client.UserLang),
+		Changes:   make([]notify.Change, 0, len(changes)),
+	}
+	for _, c := range changes {
+		oldValue, newValue := c.Old, c.New
+		if c.Field == service.ChangeTitle {
+			oldValue = c.Old.(service.Title).In(client.UserLang)
+			newValue = c.New.(service.Title).In(client.UserLang)
+		}
+		data.Changes = append(data.Changes, notify.Change{
+			Field: string(c.Field),
+			Old:   oldValue,
+			New:   newValue,
+		})
+	}
+
+	return n.notify.SendWithKeyboard(
+		ctx,
+		client.UserTgID,
+		client.UserLang,
+		notify.HikeChanged,
+		data,
+		notify.HikeChangedKeyboard(client.UserLang, client.BookingID),
+	)
+}
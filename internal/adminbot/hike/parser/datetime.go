@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dd.mm[.yyyy] [в] hh:mm
+var reDateTime = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?\s+(?:в\s+)?(\d{1,2}):(\d{2})$`)
+
+// ParseDateTime parses a moment like "01.06 10:00", "01.06 в 10:00" or
+// "01.06.2026 10:00". Without a year the nearest such moment after now is taken.
+func ParseDateTime(input string, now time.Time, loc *time.Location) (time.Time, error) {
+	m := reDateTime.FindStringSubmatch(strings.ToLower(strings.TrimSpace(input)))
+	if m == nil {
+		return time.Time{}, errors.New("ожидал dd.mm hh:mm")
+	}
+
+	day, _ := strconv.Atoi(m[1])
+	mon, _ := strconv.Atoi(m[2])
+	hour, _ := strconv.Atoi(m[4])
+	minute, _ := strconv.Atoi(m[5])
+
+	if mon < 1 || mon > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 {
+		return time.Time{}, errors.New("некорректная дата или время")
+	}
+
+	year := now.Year()
+	if m[3] != "" {
+		year, _ = strconv.Atoi(m[3])
+	}
+
+	t := time.Date(year, time.Month(mon), day, hour, minute, 0, 0, loc)
+	if t.Day() != day {
+		return time.Time{}, errors.New("такой даты нет")
+	}
+
+	if m[3] == "" && t.Before(now) {
+		t = t.AddDate(1, 0, 0)
+	}
+
+	return t, nil
+}
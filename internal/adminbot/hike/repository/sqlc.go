@@ -2,20 +2,29 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/admin"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type repository struct {
+	db      txBeginner
 	queries *admin.Queries
 }
 
-func New(q *admin.Queries) service.Repository {
-	return &repository{queries: q}
+func New(db txBeginner, q *admin.Queries) service.Repository {
+	return &repository{db: db, queries: q}
 }
 
 func (r repository) GetHike(ctx context.Context, id int32) (service.Hike, error) {
@@ -23,18 +32,13 @@ func (r repository) GetHike(ctx context.Context, id int32) (service.Hike, error)
 	if err != nil {
 		return service.Hike{}, logger.WrapError(err)
 	}
-	return service.Hike{
-		ID:            rawHike.ID,
-		TitleRu:       rawHike.TitleRu,
-		DescriptionRu: rawHike.DescriptionRu,
-		StartsAt:      rawHike.StartsAt,
-		EndsAt:        rawHike.EndsAt,
-		IsPublished:   rawHike.IsPublished,
-	}, nil
+	return toServiceHike(rawHike)
 }
 
-func (r repository) ListHikes(ctx context.Context, limit, offset int32) ([]service.Hike, error) {
+func (r repository) ListHikes(ctx context.Context, filter service.HikeFilter, now time.Time, limit, offset int32) ([]service.Hike, error) {
 	rawHikes, err := r.queries.ListHikes(ctx, admin.ListHikesParams{
+		Filter: string(filter),
+		Now:    now,
 		Limit:  limit,
 		Offset: offset,
 	})
@@ -50,6 +54,7 @@ func (r repository) ListHikes(ctx context.Context, limit, offset int32) ([]servi
 			StartsAt:    rawHike.StartsAt,
 			EndsAt:      rawHike.EndsAt,
 			IsPublished: rawHike.IsPublished,
+			PublishAt:   fromPgTimestamptz(rawHike.PublishAt),
 		}
 		hikes = append(hikes, h)
 	}
@@ -57,6 +62,17 @@ func (r repository) ListHikes(ctx context.Context, limit, offset int32) ([]servi
 	return hikes, nil
 }
 
+func (r repository) CountHikes(ctx context.Context, filter service.HikeFilter, now time.Time) (int64, error) {
+	count, err := r.queries.CountHikes(ctx, admin.CountHikesParams{
+		Filter: string(filter),
+		Now:    now,
+	})
+	if err != nil {
+		return 0, logger.WrapError(err)
+	}
+	return count, nil
+}
+
 func (r repository) ListActualHikes(ctx context.Context, limit, offset int32) ([]service.Hike, error) {
 	rawActHikes, err := r.queries.ListActualHikes(ctx, admin.ListActualHikesParams{
 		Limit:  limit,
@@ -88,6 +104,14 @@ func (r repository) PublishHike(ctx context.Context, id int32) error {
 	})
 }
 
+func (r repository) PublishDue(ctx context.Context, now time.Time) (int64, error) {
+	published, err := r.queries.PublishDueHikes(ctx, now)
+	if err != nil {
+		return 0, logger.WrapError(err)
+	}
+	return published, nil
+}
+
 func (r repository) HideHike(ctx context.Context, id int32) error {
 	return r.queries.SetPublished(ctx, admin.SetPublishedParams{
 		ID:          id,
@@ -100,44 +124,260 @@ func (r repository) DeleteHike(ctx context.Context, id int32) error {
 }
 
 func (r repository) CreateHike(ctx context.Context, hike service.Hike) (int32, error) {
-	photoFileID := pgtype.Text{
-		String: hike.PhotoFileID,
-		Valid:  hike.PhotoFileID != "",
+	params, err := toCreateHikeParams(hike)
+	if err != nil {
+		return 0, logger.WrapError(err)
 	}
 
-	distanceKm := pgtype.Numeric{}
-	if hike.DistanceKm != 0 {
-		if err := distanceKm.Scan(fmt.Sprintf("%.2f", hike.DistanceKm)); err != nil {
-			return 0, logger.WrapError(err)
+	return r.queries.CreateHike(ctx, params)
+}
+
+func (r repository) CloneHikes(ctx context.Context, source service.Hike, copies []service.Hike) ([]int32, int32, error) {
+	ids := make([]int32, 0, len(copies))
+	seriesID := source.SeriesID
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		q := r.queries.WithTx(tx)
+
+		if seriesID == 0 {
+			var err error
+			if seriesID, err = q.CreateHikeSeries(ctx); err != nil {
+				return err
+			}
+
+			err = q.SetHikeSeries(ctx, admin.SetHikeSeriesParams{
+				ID:       source.ID,
+				SeriesID: toPgInt4(seriesID),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, hike := range copies {
+			hike.SeriesID = seriesID
+
+			params, err := toCreateHikeParams(hike)
+			if err != nil {
+				return err
+			}
+
+			id, err := q.CreateHike(ctx, params)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
 		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, 0, logger.WrapError(err)
 	}
 
-	elevationGainM := pgtype.Int4{
-		Int32: int32(hike.ElevationGainM),
-		Valid: hike.ElevationGainM != 0,
+	return ids, seriesID, nil
+}
+
+func (r repository) UpdateHike(ctx context.Context, hike service.Hike, resetReminders bool) (service.Hike, error) {
+	distanceKm, err := toPgNumeric(hike.DistanceKm)
+	if err != nil {
+		return service.Hike{}, logger.WrapError(err)
 	}
 
-	return r.queries.CreateHike(ctx, admin.CreateHikeParams{
-		TitleRu:        hike.TitleRu,
-		PreviewRu:      hike.PreviewRu,
-		DescriptionRu:  hike.DescriptionRu,
-		StartsAt:       hike.StartsAt,
-		EndsAt:         hike.EndsAt,
-		PhotoFileID:    photoFileID,
-		PriceGel:       hike.PriceGel,
-		DistanceKm:     distanceKm,
-		ElevationGainM: elevationGainM,
+	var rawHike admin.Hike
+
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		q := r.queries.WithTx(tx)
+
+		var err error
+		rawHike, err = q.UpdateHike(ctx, admin.UpdateHikeParams{
+			ID:              hike.ID,
+			TitleRu:         hike.TitleRu,
+			PreviewRu:       hike.PreviewRu,
+			TitleEn:         toPgText(hike.TitleEn),
+			PreviewEn:       toPgText(hike.PreviewEn),
+			DescriptionRu:   hike.DescriptionRu,
+			DescriptionEn:   toPgText(hike.DescriptionEn),
+			StartsAt:        hike.StartsAt,
+			EndsAt:          hike.EndsAt,
+			PhotoFileID:     toPgText(hike.PhotoFileID),
+			PriceGel:        hike.PriceGel,
+			DistanceKm:      distanceKm,
+			ElevationGainM:  toPgInt4(int32(hike.ElevationGainM)),
+			MaxParticipants: toPgInt4(hike.MaxParticipants),
+			PublishAt:       toPgTimestamptz(hike.PublishAt),
+			UnpublishAt:     toPgTimestamptz(hike.UnpublishAt),
+			Difficulty:      string(hike.Difficulty),
+			Tags:            trail.TagStrings(hike.Tags),
+			UpdatedAt:       hike.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Reminders already sent for the old date must go out again
+		if resetReminders {
+			return q.DeleteHikeReminders(ctx, hike.ID)
+		}
+		return nil
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return service.Hike{}, service.ErrHikeChanged
+	}
+	if err != nil {
+		return service.Hike{}, logger.WrapError(err)
+	}
+
+	return toServiceHike(rawHike)
 }
 
 func (r repository) UpdateImagePath(ctx context.Context, hikeID int32, imagePath string) error {
-	imagePathText := pgtype.Text{
-		String: imagePath,
-		Valid:  imagePath != "",
-	}
-
 	return r.queries.UpdateImagePath(ctx, admin.UpdateImagePathParams{
 		ID:        hikeID,
-		ImagePath: imagePathText,
+		ImagePath: toPgText(imagePath),
 	})
 }
+
+func (r repository) ListReviews(ctx context.Context, hikeID int32) ([]service.Review, error) {
+	rows, err := r.queries.ListHikeReviews(ctx, hikeID)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	reviews := make([]service.Review, 0, len(rows))
+	for _, row := range rows {
+		reviews = append(reviews, service.Review{
+			ID:           row.ID,
+			BookingID:    row.BookingID,
+			Rating:       int(row.Rating),
+			Comment:      row.Comment.String,
+			UserName:     row.UserName,
+			UserUsername: row.UserUsername,
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+
+	return reviews, nil
+}
+
+func (r repository) ListActiveBookings(ctx context.Context, hikeID int32) ([]service.BookedClient, error) {
+	rows, err := r.queries.ListHikeActiveBookings(ctx, hikeID)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	clients := make([]service.BookedClient, 0, len(rows))
+	for _, row := range rows {
+		clients = append(clients, service.BookedClient{
+			BookingID: row.ID,
+			UserTgID:  row.UserTgID,
+			UserLang:  row.UserLang,
+		})
+	}
+
+	return clients, nil
+}
+
+func toCreateHikeParams(hike service.Hike) (admin.CreateHikeParams, error) {
+	distanceKm, err := toPgNumeric(hike.DistanceKm)
+	if err != nil {
+		return admin.CreateHikeParams{}, err
+	}
+
+	return admin.CreateHikeParams{
+		TitleRu:         hike.TitleRu,
+		PreviewRu:       hike.PreviewRu,
+		TitleEn:         toPgText(hike.TitleEn),
+		PreviewEn:       toPgText(hike.PreviewEn),
+		DescriptionRu:   hike.DescriptionRu,
+		DescriptionEn:   toPgText(hike.DescriptionEn),
+		StartsAt:        hike.StartsAt,
+		EndsAt:          hike.EndsAt,
+		PhotoFileID:     toPgText(hike.PhotoFileID),
+		PriceGel:        hike.PriceGel,
+		DistanceKm:      distanceKm,
+		ElevationGainM:  toPgInt4(int32(hike.ElevationGainM)),
+		MaxParticipants: toPgInt4(hike.MaxParticipants),
+		PublishAt:       toPgTimestamptz(hike.PublishAt),
+		UnpublishAt:     toPgTimestamptz(hike.UnpublishAt),
+		SeriesID:        toPgInt4(hike.SeriesID),
+		Difficulty:      string(hike.Difficulty),
+		Tags:            trail.TagStrings(hike.Tags),
+	}, nil
+}
+
+func toServiceHike(rawHike admin.Hike) (service.Hike, error) {
+	var distance float64
+	if rawHike.DistanceKm.Valid {
+		result, err := rawHike.DistanceKm.Float64Value()
+		if err != nil {
+			return service.Hike{}, logger.WrapError(err)
+		}
+		distance = result.Float64
+	}
+
+	return service.Hike{
+		ID:              rawHike.ID,
+		TitleRu:         rawHike.TitleRu,
+		TitleEn:         rawHike.TitleEn.String,
+		PreviewRu:       rawHike.PreviewRu,
+		PreviewEn:       rawHike.PreviewEn.String,
+		DescriptionRu:   rawHike.DescriptionRu,
+		DescriptionEn:   rawHike.DescriptionEn.String,
+		PriceGel:        rawHike.PriceGel,
+		DistanceKm:      distance,
+		ElevationGainM:  int(rawHike.ElevationGainM.Int32),
+		Difficulty:      trail.Difficulty(rawHike.Difficulty),
+		Tags:            trail.ParseTags(rawHike.Tags),
+		MaxParticipants: rawHike.MaxParticipants.Int32,
+		StartsAt:        rawHike.StartsAt,
+		EndsAt:          rawHike.EndsAt,
+		PhotoFileID:     rawHike.PhotoFileID.String,
+		ImagePath:       rawHike.ImagePath.String,
+		IsPublished:     rawHike.IsPublished,
+		PublishAt:       fromPgTimestamptz(rawHike.PublishAt),
+		UnpublishAt:     fromPgTimestamptz(rawHike.UnpublishAt),
+		SeriesID:        rawHike.SeriesID.Int32,
+		CreatedAt:       rawHike.CreatedAt,
+		UpdatedAt:       rawHike.UpdatedAt,
+	}, nil
+}
+
+func toPgText(s string) pgtype.Text {
+	return pgtype.Text{
+		String: s,
+		Valid:  s != "",
+	}
+}
+
+func toPgInt4(i int32) pgtype.Int4 {
+	return pgtype.Int4{
+		Int32: i,
+		Valid: i != 0,
+	}
+}
+
+func toPgTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+func fromPgTimestamptz(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func toPgNumeric(f float64) (pgtype.Numeric, error) {
+	n := pgtype.Numeric{}
+	if f == 0 {
+		return n, nil
+	}
+	if err := n.Scan(fmt.Sprintf("%.2f", f)); err != nil {
+		return pgtype.Numeric{}, err
+	}
+	return n, nil
+}
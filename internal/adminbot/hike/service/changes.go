@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+)
+
+// ChangeField names a hike field clients are told about when it changes.
+type ChangeField string
+
+const (
+	ChangeTitle    ChangeField = "title"
+	ChangeStartsAt ChangeField = "starts_at"
+	ChangeEndsAt   ChangeField = "ends_at"
+	ChangePrice    ChangeField = "price"
+)
+
+var ErrClientNotification = errors.New("client notification failed")
+
+// Change is a material change of a hike, Old and New hold values of the field
+// type. Titles are Title values, so each client gets them in their language.
+type Change struct {
+	Field ChangeField
+	Old   any
+	New   any
+}
+
+// Title is the value of a ChangeTitle change: the hike title in both languages.
+type Title struct {
+	Ru string
+	En string
+}
+
+// In returns the title in the language, falling back to Russian.
+func (t Title) In(lang string) string {
+	return i18n.Localized(lang, t.Ru, t.En)
+}
+
+// BookedClient is a client with an active booking on the hike.
+type BookedClient struct {
+	BookingID int32
+	UserTgID  int64
+	UserLang  string
+}
+
+// DetectChanges lists the changes between two versions of a hike that
+// matter to booked clients. Description, photo and other details don't.
+func DetectChanges(before, after Hike) []Change {
+	var changes []Change
+
+	if before.TitleRu != after.TitleRu || before.TitleEn != after.TitleEn {
+		changes = append(changes, Change{
+			Field: ChangeTitle,
+			Old:   Title{Ru: before.TitleRu, En: before.TitleEn},
+			New:   Title{Ru: after.TitleRu, En: after.TitleEn},
+		})
+	}
+	if !before.StartsAt.Equal(after.StartsAt) {
+		changes = append(changes, Change{Field: ChangeStartsAt, Old: before.StartsAt, New: after.StartsAt})
+	}
+	if !before.EndsAt.Equal(after.EndsAt) {
+		changes = append(changes, Change{Field: ChangeEndsAt, Old: before.EndsAt, New: after.EndsAt})
+	}
+	if before.PriceGel != after.PriceGel {
+		changes = append(changes, Change{Field: ChangePrice, Old: before.PriceGel, New: after.PriceGel})
+	}
+
+	return changes
+}
+
+// notifyChanged sends the changes to every client with an active booking on the hike.
+func (s service) notifyChanged(ctx context.Context, hike Hike, changes []Change) error {
+	clients, err := s.repo.ListActiveBookings(ctx, hike.ID)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, c := range clients {
+		if err := s.notifier.NotifyHikeChanged(ctx, c, hike, changes); err != nil {
+			errs = append(errs, fmt.Errorf("booking id=%d: %w", c.BookingID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
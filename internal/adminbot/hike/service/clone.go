@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// MaxSeriesLength limits how many hikes one series creates at once.
+const MaxSeriesLength = 12
+
+var ErrNoOccurrences = errors.New("no occurrences to clone")
+
+// Occurrence holds the dates of one copy of a hike.
+type Occurrence struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+}
+
+// Repeat returns count occurrences starting with first, the given number
+// of weeks apart. Calendar days are added, so the time of day survives DST.
+func Repeat(first Occurrence, count, weeks int) []Occurrence {
+	occurrences := make([]Occurrence, 0, count)
+	for i := 0; i < count; i++ {
+		days := i * weeks * 7
+		occurrences = append(occurrences, Occurrence{
+			StartsAt: first.StartsAt.AddDate(0, 0, days),
+			EndsAt:   first.EndsAt.AddDate(0, 0, days),
+		})
+	}
+	return occurrences
+}
+
+func (s service) CloneHike(ctx context.Context, source Hike, occurrences []Occurrence) ([]Hike, error) {
+	if len(occurrences) == 0 {
+		return nil, ErrNoOccurrences
+	}
+
+	copies := make([]Hike, 0, len(occurrences))
+	for _, o := range occurrences {
+		copies = append(copies, cloneHike(source, o))
+	}
+
+	ids, seriesID, err := s.repo.CloneHikes(ctx, source, copies)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range copies {
+		copies[i].ID = ids[i]
+		copies[i].SeriesID = seriesID
+	}
+
+	return copies, nil
+}
+
+// cloneHike copies the content of the hike to new dates. The copy is not
+// published and has no image yet, booking closes as long before the start
+// as it did for the source.
+func cloneHike(source Hike, o Occurrence) Hike {
+	clone := source
+	clone.ID = 0
+	clone.StartsAt = o.StartsAt
+	clone.EndsAt = o.EndsAt
+	clone.ImagePath = ""
+	clone.IsPublished = false
+	clone.PublishAt = nil
+	clone.SeriesID = 0
+
+	if source.UnpublishAt != nil {
+		unpublishAt := o.StartsAt.Add(source.UnpublishAt.Sub(source.StartsAt))
+		clone.UnpublishAt = &unpublishAt
+	}
+
+	return clone
+}
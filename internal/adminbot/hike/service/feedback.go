@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+)
+
+type Review struct {
+	ID           int32
+	BookingID    int32
+	Rating       int
+	Comment      string
+	UserName     string
+	UserUsername string
+	CreatedAt    time.Time
+}
+
+// Feedback is the per-hike report of client reviews, newest first.
+type Feedback struct {
+	Reviews []Review
+	// ByRating counts reviews per star, ByRating[0] is for 1 star
+	ByRating [trail.MaxRating]int
+	Average  float64
+}
+
+func (s service) GetFeedback(ctx context.Context, hikeID int32) (Feedback, error) {
+	reviews, err := s.repo.ListReviews(ctx, hikeID)
+	if err != nil {
+		return Feedback{}, err
+	}
+
+	feedback := Feedback{Reviews: reviews}
+	if len(reviews) == 0 {
+		return feedback, nil
+	}
+
+	var sum int
+	for _, r := range reviews {
+		if r.Rating >= trail.MinRating && r.Rating <= trail.MaxRating {
+			feedback.ByRating[r.Rating-1]++
+		}
+		sum += r.Rating
+	}
+	feedback.Average = float64(sum) / float64(len(reviews))
+
+	return feedback, nil
+}
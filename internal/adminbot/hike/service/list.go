@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// HikeFilter narrows the admin hike list. Published and draft hikes are
+// upcoming or running ones, finished hikes are only listed as past.
+type HikeFilter string
+
+const (
+	FilterAll       HikeFilter = "all"
+	FilterPublished HikeFilter = "published"
+	FilterDraft     HikeFilter = "draft"
+	FilterPast      HikeFilter = "past"
+)
+
+func (f HikeFilter) Valid() bool {
+	switch f {
+	case FilterAll, FilterPublished, FilterDraft, FilterPast:
+		return true
+	default:
+		return false
+	}
+}
+
+type HikePage struct {
+	Hikes  []Hike
+	Filter HikeFilter
+	// Page is 1-based, Pages is 0 when no hike matches the filter.
+	Page  int32
+	Pages int32
+}
+
+func (p HikePage) HasPrev() bool {
+	return p.Page > 1
+}
+
+func (p HikePage) HasNext() bool {
+	return p.Page < p.Pages
+}
+
+func (s service) ListHikes(ctx context.Context, filter HikeFilter, page, size int32) (HikePage, error) {
+	if !filter.Valid() {
+		filter = FilterAll
+	}
+
+	now := time.Now()
+
+	total, err := s.repo.CountHikes(ctx, filter, now)
+	if err != nil {
+		return HikePage{}, err
+	}
+
+	pages := int32((total + int64(size) - 1) / int64(size))
+
+	// The list may have shrunk since the admin opened the page.
+	page = max(min(page, pages), 1)
+
+	hikes, err := s.repo.ListHikes(ctx, filter, now, size, (page-1)*size)
+	if err != nil {
+		return HikePage{}, err
+	}
+
+	return HikePage{
+		Hikes:  hikes,
+		Filter: filter,
+		Page:   page,
+		Pages:  pages,
+	}, nil
+}
@@ -2,63 +2,130 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+)
+
+var (
+	// ErrHikeChanged is returned by UpdateHike when the hike was changed after it was read.
+	ErrHikeChanged       = errors.New("hike changed concurrently")
+	ErrWaitlistPromotion = errors.New("waitlist promotion failed")
 )
 
 type Hike struct {
-	ID             int32
-	TitleRu        string
-	PreviewRu      string
-	DescriptionRu  string
-	PriceGel       int32
-	DistanceKm     float64
-	ElevationGainM int
-	StartsAt       time.Time
-	EndsAt         time.Time
-	PhotoFileID    string
-	ImagePath      string
-	IsPublished    bool
+	ID              int32
+	TitleRu         string
+	TitleEn         string
+	PreviewRu       string
+	PreviewEn       string
+	DescriptionRu   string
+	DescriptionEn   string
+	PriceGel        int32
+	DistanceKm      float64
+	ElevationGainM  int
+	Difficulty      trail.Difficulty
+	Tags            []trail.Tag
+	MaxParticipants int32
+	StartsAt        time.Time
+	EndsAt          time.Time
+	PhotoFileID     string
+	ImagePath       string
+	IsPublished     bool
+	// PublishAt is when PublishDue publishes the hike, nil means manual publishing.
+	PublishAt *time.Time
+	// UnpublishAt closes booking: the hike disappears from the client list before it ends.
+	UnpublishAt *time.Time
+	// SeriesID links a hike with its copies made by CloneHike, so clients see
+	// the reviews of past occurrences. 0 if the hike was never cloned.
+	SeriesID  int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// TitleIn returns the hike title in the language, falling back to Russian.
+func (h Hike) TitleIn(lang string) string {
+	return Title{Ru: h.TitleRu, En: h.TitleEn}.In(lang)
 }
 
 type Repository interface {
 	GetHike(ctx context.Context, id int32) (Hike, error)
-	ListHikes(ctx context.Context, limit, offset int32) ([]Hike, error)
+	ListHikes(ctx context.Context, filter HikeFilter, now time.Time, limit, offset int32) ([]Hike, error)
+	CountHikes(ctx context.Context, filter HikeFilter, now time.Time) (int64, error)
 	ListActualHikes(ctx context.Context, limit, offset int32) ([]Hike, error)
 	PublishHike(ctx context.Context, id int32) error
+	// PublishDue returns the number of published hikes.
+	PublishDue(ctx context.Context, now time.Time) (int64, error)
 	CreateHike(ctx context.Context, hike Hike) (int32, error)
+	// CloneHikes creates the copies in one transaction in the series of the
+	// source, starting a series with the source if it has none. It returns
+	// the IDs in the order of copies and the series ID.
+	CloneHikes(ctx context.Context, source Hike, copies []Hike) ([]int32, int32, error)
+	// UpdateHike saves the editable fields of the hike if it still has the
+	// UpdatedAt it was read with, otherwise it returns ErrHikeChanged.
+	// The publication state is left to PublishHike and HideHike.
+	// resetReminders forgets the reminders sent to the booked clients,
+	// so they are sent again before the new start.
+	UpdateHike(ctx context.Context, hike Hike, resetReminders bool) (Hike, error)
 	UpdateImagePath(ctx context.Context, hikeID int32, imagePath string) error
 	HideHike(ctx context.Context, id int32) error
 	DeleteHike(ctx context.Context, id int32) error
+	ListReviews(ctx context.Context, hikeID int32) ([]Review, error)
+	ListActiveBookings(ctx context.Context, hikeID int32) ([]BookedClient, error)
+}
+
+type Notifier interface {
+	NotifyHikeChanged(ctx context.Context, client BookedClient, hike Hike, changes []Change) error
 }
 
 type Service interface {
 	GetHike(ctx context.Context, id int32) (Hike, error)
-	ListHikes(ctx context.Context, page, size int32) ([]Hike, error)
+	// ListHikes returns a page of hikes matching the filter, see HikePage.
+	ListHikes(ctx context.Context, filter HikeFilter, page, size int32) (HikePage, error)
 	ListActualHikes(ctx context.Context, page, size int32) ([]Hike, error)
 	PublishHike(ctx context.Context, id int32) error
+	// PublishDue publishes hikes scheduled for publishing at or before now.
+	PublishDue(ctx context.Context, now time.Time) error
 	CreateHike(ctx context.Context, hike Hike) (int32, error)
+	// CloneHike creates unpublished copies of the hike, one per occurrence,
+	// in the series of the source. The copies are returned with their IDs,
+	// images are left to the caller.
+	CloneHike(ctx context.Context, source Hike, occurrences []Occurrence) ([]Hike, error)
+	// UpdateHike saves the hike and tells clients with active bookings about
+	// material changes, added seats go to the waitlist and a new start date
+	// makes the hike reminders go out again. ErrHikeChanged means
+	// the hike was changed after it was read and nothing was saved. Failed
+	// side effects are returned wrapped in ErrClientNotification /
+	// ErrWaitlistPromotion together with the saved hike.
+	UpdateHike(ctx context.Context, hike Hike) (Hike, error)
 	UpdateImagePath(ctx context.Context, hikeID int32, imagePath string) error
 	HideHike(ctx context.Context, id int32) error
 	DeleteHike(ctx context.Context, id int32) error
+	// GetFeedback collects client ratings and reviews of the hike.
+	GetFeedback(ctx context.Context, hikeID int32) (Feedback, error)
+}
+
+// Waitlist gives seats added by a larger capacity to waitlisted clients.
+type Waitlist interface {
+	Promote(ctx context.Context, hikeID int32) error
 }
 
 type service struct {
-	repo Repository
+	repo     Repository
+	notifier Notifier
+	waitlist Waitlist
 }
 
-func New(r Repository) Service {
-	return &service{repo: r}
+func New(r Repository, n Notifier, w Waitlist) Service {
+	return &service{repo: r, notifier: n, waitlist: w}
 }
 
 func (s service) GetHike(ctx context.Context, id int32) (Hike, error) {
 	return s.repo.GetHike(ctx, id)
 }
 
-func (s service) ListHikes(ctx context.Context, page, size int32) ([]Hike, error) {
-	offset := (page - 1) * size
-	return s.repo.ListHikes(ctx, size, offset)
-}
-
 func (s service) ListActualHikes(ctx context.Context, page, size int32) ([]Hike, error) {
 	offset := (page - 1) * size
 	return s.repo.ListActualHikes(ctx, size, offset)
@@ -68,10 +135,48 @@ func (s service) PublishHike(ctx context.Context, id int32) error {
 	return s.repo.PublishHike(ctx, id)
 }
 
+func (s service) PublishDue(ctx context.Context, now time.Time) error {
+	_, err := s.repo.PublishDue(ctx, now)
+	return err
+}
+
 func (s service) CreateHike(ctx context.Context, hike Hike) (int32, error) {
 	return s.repo.CreateHike(ctx, hike)
 }
 
+func (s service) UpdateHike(ctx context.Context, hike Hike) (Hike, error) {
+	before, err := s.repo.GetHike(ctx, hike.ID)
+	if err != nil {
+		return Hike{}, err
+	}
+
+	updated, err := s.repo.UpdateHike(ctx, hike, !hike.StartsAt.Equal(before.StartsAt))
+	if err != nil {
+		return Hike{}, err
+	}
+
+	var errs []error
+
+	if changes := DetectChanges(before, updated); len(changes) > 0 {
+		if err := s.notifyChanged(ctx, updated, changes); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrClientNotification, err))
+		}
+	}
+
+	if capacityGrew(before.MaxParticipants, updated.MaxParticipants) {
+		if err := s.waitlist.Promote(ctx, updated.ID); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrWaitlistPromotion, err))
+		}
+	}
+
+	return updated, errors.Join(errs...)
+}
+
+// capacityGrew reports whether the hike got more seats, 0 means no limit.
+func capacityGrew(before, after int32) bool {
+	return before != 0 && (after == 0 || after > before)
+}
+
 func (s service) UpdateImagePath(ctx context.Context, hikeID int32, imagePath string) error {
 	return s.repo.UpdateImagePath(ctx, hikeID, imagePath)
 }
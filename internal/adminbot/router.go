@@ -7,6 +7,7 @@ import (
 	bookingHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/handler"
 	hikeHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/handler"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/ui/common"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
@@ -45,129 +46,69 @@ func (r *router) Route(ctx context.Context, u tgbot.Update) error {
 }
 
 func (r *router) routeMessage(ctx context.Context, m *tgbot.Message) error {
-	if m.Text == "⬅️ Назад" {
-		r.hikeHandler.ResetFSM(m.From.ID)
+	key, _ := i18n.KeyOf(m.Text)
+
+	if key == i18n.BtnBack {
+		if err := r.hikeHandler.LeaveFSM(ctx, m.From.ID); err != nil {
+			return err
+		}
 		return r.showMainMenu(m.Chat.ID)
 	}
 
-	if r.hikeHandler.InProgressFSM(m.From.ID) {
+	if r.hikeHandler.InProgressFSM(ctx, m.From.ID) {
 		return r.hikeHandler.HandleFSM(ctx, m)
 	}
 
-	switch m.Text {
-	case "🏔 Хайки", "➕ Создать хайк", "📋 Список хайков":
-		return r.routeHikeMessage(ctx, m)
+	switch key {
+	case i18n.BtnAdminHikes, i18n.BtnAdminCreateHike, i18n.BtnAdminListHikes:
+		return r.routeHikeMessage(ctx, m, key)
 
-	case "📥 Заявки", "📋 Список заявок", "📊 Статистика заявок":
-		return r.routeBookingMessage(ctx, m)
+	case i18n.BtnAdminBookings, i18n.BtnAdminListBookings, i18n.BtnAdminBookingStats:
+		return r.routeBookingMessage(ctx, m, key)
 
-	case "❓ Помощь":
+	case i18n.BtnAdminHelp:
 		return r.showHelp(m.Chat.ID)
 	}
 
 	return r.showMainMenu(m.Chat.ID)
 }
 
-func (r *router) routeHikeMessage(ctx context.Context, m *tgbot.Message) error {
-	switch m.Text {
-	case "🏔 Хайки":
+func (r *router) routeHikeMessage(ctx context.Context, m *tgbot.Message, key i18n.Key) error {
+	switch key {
+	case i18n.BtnAdminHikes:
 		return r.hikeHandler.ShowMenu(ctx, m)
-	case "➕ Создать хайк":
+	case i18n.BtnAdminCreateHike:
 		return r.hikeHandler.StartCreateHike(ctx, m)
-	case "📋 Список хайков":
+	case i18n.BtnAdminListHikes:
 		return r.hikeHandler.ListHikes(ctx, m)
 	}
 
 	return r.showMainMenu(m.Chat.ID)
 }
 
-func (r *router) routeBookingMessage(ctx context.Context, m *tgbot.Message) error {
-	switch m.Text {
-	case "📥 Заявки":
+func (r *router) routeBookingMessage(ctx context.Context, m *tgbot.Message, key i18n.Key) error {
+	switch key {
+	case i18n.BtnAdminBookings:
 		return r.bookingHandler.ShowMenu(ctx, m)
-	case "📋 Список заявок":
+	case i18n.BtnAdminListBookings:
 		return r.bookingHandler.ListBookings(ctx, m)
-	case "📊 Статистика заявок":
-		// TODO: return r.bookingHandler.Stat(ctx, m)
-		return nil
+	case i18n.BtnAdminBookingStats:
+		return r.bookingHandler.Stats(ctx, m)
 	}
 
 	return r.showMainMenu(m.Chat.ID)
 }
 
-// TODO: убрать из роутера
 func (r *router) showHelp(chatID int64) error {
-	text := `❓ <b>Помощь для администратора</b>
-
-━━━━━━━━━━━━━━━
-🏔 <b>Как создать хайк</b>
-
-1️⃣ Откройте раздел <b>🏔 Хайки</b>  
-2️⃣ Нажмите <b>➕ Создать хайк</b>  
-3️⃣ Заполните поля:
-• Название  
-• Описание  
-• Даты  
-• Цена  
-• Дистанция  
-• Набор высоты  
-• Фото  
-
-4️⃣ Проверьте данные  
-5️⃣ Нажмите <b>✅ Подтвердить</b>
-
-После этого хайк появится в клиентском боте
-
-━━━━━━━━━━━━━━━
-📋 <b>Работа с хайками</b>
-
-📋 Список хайков — посмотреть все хайки  
-Вы можете:
-• Опубликовать хайк  
-• Скрыть хайк  
-• Редактировать позже (в будущем)
-
-━━━━━━━━━━━━━━━
-📥 <b>Работа с заявками</b>
-
-Когда клиент бронирует хайк:
-• В админ-чате появляется заявка  
-• Любой менеджер может взять её в работу  
-
-Статусы заявок:
-🟡 В работе — менеджер взял заявку  
-🟢 Подтверждена — клиент подтвердил участие  
-🏁 Завершена — хайк состоялся  
-🔴 Отменена — заявка отменена  
-
-━━━━━━━━━━━━━━━
-📋 <b>Как работать с заявкой</b>
-
-1️⃣ Откройте <b>📋 Список заявок</b>  
-2️⃣ Выберите заявку  
-3️⃣ Нажмите нужное действие:
-• ✅ Подтвердить  
-• ❌ Отменить  
-• 🏁 Завершить  
-
-━━━━━━━━━━━━━━━
-💡 <b>Важно</b>
-
-• Новые заявки приходят автоматически  
-• Один менеджер — одна заявка  
-• После взятия заявки другие менеджеры её не обрабатывают  
-
-Если возникли проблемы — напишите разработчику 😄`
-
-	msg := tgbot.NewMessage(chatID, text)
-	msg.ParseMode = "HTML"
+	msg := tgbot.NewMessage(chatID, i18n.T(i18n.DefaultLang, i18n.AdminHelp))
+	msg.ParseMode = tgbot.ModeHTML
 
 	_, err := r.bot.Send(msg)
 	return err
 }
 
 func (r *router) showMainMenu(chatID int64) error {
-	msg := tgbot.NewMessage(chatID, "Выберите раздел")
+	msg := tgbot.NewMessage(chatID, i18n.T(i18n.DefaultLang, i18n.AdminChooseSection))
 	msg.ReplyMarkup = common.MainMenu()
 
 	_, err := r.bot.Send(msg)
@@ -177,8 +118,7 @@ func (r *router) showMainMenu(chatID int64) error {
 func (r *router) routeCallback(ctx context.Context, q *tgbot.CallbackQuery) error {
 	switch {
 	case strings.HasPrefix(q.Data, "hike:"):
-		return nil
-		// TODO: return r.hikeHandler.HandleCallback(ctx, q)
+		return r.hikeHandler.HandleCallback(ctx, q)
 
 	case strings.HasPrefix(q.Data, "booking:"):
 		return r.bookingHandler.HandleCallback(ctx, q)
@@ -3,19 +3,22 @@ package booking
 import (
 	"fmt"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 func BookingMenuKeyboard() tgbot.ReplyKeyboardMarkup {
+	lang := i18n.DefaultLang
+
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("📋 Список заявок"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminListBookings)),
 		),
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("📊 Статистика заявок"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminBookingStats)),
 		),
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("⬅️ Назад"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnBack)),
 		),
 	)
 }
@@ -5,6 +5,8 @@ import (
 	"html"
 	"strings"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/money"
+
 	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
 )
 
@@ -25,6 +27,9 @@ func AdminBookingCard(b bookingService.Booking) string {
 		sb.WriteString(fmt.Sprintf("Telegram ID: <code>%d</code>\n", b.UserTgID))
 	}
 
+	sb.WriteString(fmt.Sprintf("Оплата: %s\n", paymentStatusLabel(b.PaymentStatus)))
+	sb.WriteString(fmt.Sprintf("Оплачено: %s из %d GEL\n", money.Format(b.PaidAmount), b.HikePriceGel))
+
 	sb.WriteString(fmt.Sprintf("Создана: %s", b.CreatedAt.Format("02.01.2006 15:04")))
 
 	return sb.String()
@@ -40,7 +45,88 @@ func statusLabel(status bookingService.BookingStatus) string {
 		return "🏁 Завершена"
 	case bookingService.StatusCanceled:
 		return "❌ Отменена"
+	case bookingService.StatusWaitlisted:
+		return "⏳ Лист ожидания"
 	default:
 		return "🆕 Новая"
 	}
 }
+
+// AdminHikeCanceledMessage replaces the booking message in the admin chat
+// when the whole hike is canceled, which also removes its buttons.
+func AdminHikeCanceledMessage(b bookingService.Booking) string {
+	clientName := html.EscapeString(strings.TrimSpace(b.UserName))
+	if clientName == "" {
+		clientName = "—"
+	}
+
+	unameLine := "—"
+	if strings.TrimSpace(b.UserUsername) != "" {
+		unameLine = "@" + html.EscapeString(b.UserUsername)
+	}
+
+	reason := b.CancelReason
+	if reason == "" {
+		reason = "не указана"
+	}
+
+	return fmt.Sprintf(
+		"📦 ID заявки: %d\n"+
+			"📍 Хайк: %s\n"+
+			"🗓 Дата: %s\n\n"+
+			"Данные клиента\n"+
+			"🔗 Username: %s\n"+
+			"👤 Пользователь: <a href=\"tg://user?id=%d\">%s</a>\n\n"+
+			"🔴 <b>Хайк отменён</b>\nПричина: %s",
+		b.ID,
+		html.EscapeString(b.HikeTitle),
+		b.HikeStartsAt.Format("02.01.2006 15:04"),
+		unameLine,
+		b.UserTgID,
+		clientName,
+		html.EscapeString(reason),
+	)
+}
+
+// AdminBookingExpiredMessage replaces the booking message in the admin chat
+// when nobody took the booking in time, which also removes its buttons.
+func AdminBookingExpiredMessage(b bookingService.Booking) string {
+	clientName := html.EscapeString(strings.TrimSpace(b.UserName))
+	if clientName == "" {
+		clientName = "—"
+	}
+
+	unameLine := "—"
+	if strings.TrimSpace(b.UserUsername) != "" {
+		unameLine = "@" + html.EscapeString(b.UserUsername)
+	}
+
+	return fmt.Sprintf(
+		"📦 ID заявки: %d\n"+
+			"📍 Хайк: %s\n"+
+			"🗓 Дата: %s\n\n"+
+			"Данные клиента\n"+
+			"🔗 Username: %s\n"+
+			"👤 Пользователь: <a href=\"tg://user?id=%d\">%s</a>\n\n"+
+			"⌛ <b>Заявка истекла</b> — её не взяли в работу, клиент уведомлён",
+		b.ID,
+		html.EscapeString(b.HikeTitle),
+		b.HikeStartsAt.Format("02.01.2006 15:04"),
+		unameLine,
+		b.UserTgID,
+		clientName,
+	)
+}
+
+// AdminBookingEscalationMessage is a reply to a booking message nobody took for too long.
+func AdminBookingEscalationMessage(b bookingService.Booking) string {
+	return fmt.Sprintf(
+		"⏰ <b>Заявку #%d никто не взял в работу</b>\n\n"+
+			"📍 Хайк: %s\n"+
+			"🕓 Ждёт с %s\n\n"+
+			"Возьмите её, пожалуйста, пока она не истекла.",
+		b.ID,
+		html.EscapeString(b.HikeTitle),
+		b.CreatedAt.Format("02.01.2006 15:04"),
+	)
+}
@@ -0,0 +1,48 @@
+package booking
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/money"
+
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
+)
+
+func ClientPaymentReviewedMessage(p bookingService.Payment) string {
+	if p.Status == bookingService.PaymentVerified {
+		return fmt.Sprintf(
+			"✅ Оплата по хайку <b>%s</b> подтверждена: %s %s. До встречи на маршруте!",
+			html.EscapeString(p.HikeTitle),
+			money.Format(p.Amount),
+			p.Currency,
+		)
+	}
+
+	return fmt.Sprintf(
+		"❌ Менеджер не смог подтвердить оплату по хайку <b>%s</b> (%s %s).\n\n"+
+			"Проверьте чек и отправьте его ещё раз в разделе «🧾 Мои записи» или свяжитесь с менеджером.",
+		html.EscapeString(p.HikeTitle),
+		money.Format(p.Amount),
+		p.Currency,
+	)
+}
+
+// PaymentReviewedCaption marks the receipt caption with the review result.
+// The caption comes from Telegram as plain text, so it is escaped again.
+func PaymentReviewedCaption(caption string, status bookingService.PaymentStatus) string {
+	return html.EscapeString(caption) + "\n\n" + paymentStatusLabel(status)
+}
+
+func paymentStatusLabel(status bookingService.PaymentStatus) string {
+	switch status {
+	case bookingService.PaymentSubmitted:
+		return "🟡 Чек на проверке"
+	case bookingService.PaymentVerified:
+		return "✅ Оплата подтверждена"
+	case bookingService.PaymentRejected:
+		return "❌ Чек отклонён"
+	default:
+		return "— Чека нет"
+	}
+}
@@ -0,0 +1,118 @@
+package booking
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/money"
+
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
+)
+
+var rosterHeader = []string{"№", "Статус", "Имя", "Username", "Telegram ID", "Телефон", "Оплата", "Оплачено, GEL"}
+
+// utf8BOM makes Excel open the CSV as UTF-8.
+const utf8BOM = "\uFEFF"
+
+func RosterFileName(hikeID int32, startsAt time.Time, ext string) string {
+	return fmt.Sprintf("hike-%d-%s-roster.%s", hikeID, startsAt.Format("2006-01-02"), ext)
+}
+
+func RosterCaption(title string, startsAt time.Time, bookings []bookingService.Booking) string {
+	var confirmed int
+	for _, b := range bookings {
+		if b.Status == bookingService.StatusConfirmed || b.Status == bookingService.StatusCompleted {
+			confirmed++
+		}
+	}
+
+	return fmt.Sprintf(
+		"📋 <b>Участники хайка</b>\n%s, %s\n\nПодтверждено: %d, всего заявок: %d",
+		html.EscapeString(title),
+		startsAt.Format("02.01.2006 15:04"),
+		confirmed,
+		len(bookings),
+	)
+}
+
+func RosterCSV(bookings []bookingService.Booking) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+
+	w := csv.NewWriter(&buf)
+	if err := w.Write(rosterHeader); err != nil {
+		return nil, err
+	}
+
+	for i, b := range bookings {
+		if err := w.Write(rosterRow(i, b)); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RosterHTML is a printable version of the roster with an empty column for
+// the guide to tick off participants.
+func RosterHTML(title string, startsAt time.Time, bookings []bookingService.Booking) []byte {
+	var sb strings.Builder
+
+	sb.WriteString("<!DOCTYPE html>\n<html lang=\"ru\">\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	sb.WriteString("<style>\n" +
+		"body { font-family: sans-serif; font-size: 12pt; }\n" +
+		"table { border-collapse: collapse; width: 100%; }\n" +
+		"th, td { border: 1px solid #444; padding: 4px 6px; text-align: left; }\n" +
+		"th { background: #eee; }\n" +
+		"</style>\n</head>\n<body>\n")
+
+	sb.WriteString(fmt.Sprintf("<h1>%s</h1>\n", html.EscapeString(title)))
+	sb.WriteString(fmt.Sprintf("<p>%s · заявок: %d</p>\n", startsAt.Format("02.01.2006 15:04"), len(bookings)))
+
+	sb.WriteString("<table>\n<tr>")
+	for _, h := range rosterHeader {
+		sb.WriteString("<th>" + html.EscapeString(h) + "</th>")
+	}
+	sb.WriteString("<th>✓</th></tr>\n")
+
+	for i, b := range bookings {
+		sb.WriteString("<tr>")
+		for _, cell := range rosterRow(i, b) {
+			sb.WriteString("<td>" + html.EscapeString(cell) + "</td>")
+		}
+		sb.WriteString("<td></td></tr>\n")
+	}
+
+	sb.WriteString("</table>\n</body>\n</html>\n")
+
+	return []byte(sb.String())
+}
+
+func rosterRow(i int, b bookingService.Booking) []string {
+	username := ""
+	if b.UserUsername != "" {
+		username = "@" + b.UserUsername
+	}
+
+	return []string{
+		strconv.Itoa(i + 1),
+		statusLabel(b.Status),
+		strings.TrimSpace(b.UserName),
+		username,
+		strconv.FormatInt(b.UserTgID, 10),
+		b.UserPhone,
+		paymentStatusLabel(b.PaymentStatus),
+		fmt.Sprintf("%s из %d", money.Format(b.PaidAmount), b.HikePriceGel),
+	}
+}
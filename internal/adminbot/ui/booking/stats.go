@@ -0,0 +1,189 @@
+package booking
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/money"
+
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/booking/service"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// statsListLimit keeps the stats message well below the Telegram limit.
+const statsListLimit = 10
+
+var statsStatusOrder = []bookingService.BookingStatus{
+	bookingService.StatusNew,
+	bookingService.StatusWaitlisted,
+	bookingService.StatusInProgress,
+	bookingService.StatusConfirmed,
+	bookingService.StatusCompleted,
+	bookingService.StatusCanceled,
+}
+
+func StatsMessage(s bookingService.Stats) string {
+	var sb strings.Builder
+
+	sb.WriteString("📊 <b>Статистика заявок</b>\n")
+	sb.WriteString(fmt.Sprintf("Период: %s\n\n", statsPeriodLabel(s)))
+
+	total := s.Total()
+	if total == 0 {
+		sb.WriteString("За этот период заявок нет.")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Всего заявок: <b>%d</b>\n", total))
+	for _, status := range statsStatusOrder {
+		if n := s.ByStatus[status]; n > 0 {
+			sb.WriteString(fmt.Sprintf("%s: %d\n", statusLabel(status), n))
+		}
+	}
+
+	confirmed := s.Confirmed()
+	completed := s.ByStatus[bookingService.StatusCompleted]
+
+	sb.WriteString("\n<b>Конверсия</b>\n")
+	sb.WriteString(fmt.Sprintf("Новая → подтверждена: %d из %d (%s)\n", confirmed, total, percent(confirmed, total)))
+	sb.WriteString(fmt.Sprintf("Подтверждена → завершена: %d из %d (%s)\n", completed, confirmed, percent(completed, confirmed)))
+
+	sb.WriteString("\n⏱ Среднее время до взятия в работу: ")
+	if s.Taken > 0 {
+		sb.WriteString(fmt.Sprintf("%s (заявок: %d)\n", formatDuration(s.AvgTakeTime), s.Taken))
+	} else {
+		sb.WriteString("—\n")
+	}
+
+	if len(s.ByHike) > 0 {
+		sb.WriteString("\n🏔 <b>По хайкам</b>\n")
+		for i, h := range s.ByHike {
+			if i == statsListLimit {
+				sb.WriteString(fmt.Sprintf("…и ещё %d\n", len(s.ByHike)-statsListLimit))
+				break
+			}
+			sb.WriteString(fmt.Sprintf(
+				"%d. %s (%s) — %d: ✅ %d, 🏁 %d, ❌ %d\n",
+				i+1,
+				html.EscapeString(h.HikeTitle),
+				h.HikeStartsAt.Format("02.01"),
+				h.Total,
+				h.Confirmed,
+				h.Completed,
+				h.Canceled,
+			))
+		}
+	}
+
+	if len(s.ByAdmin) > 0 {
+		sb.WriteString("\n👤 <b>По менеджерам</b>\n")
+		for i, a := range s.ByAdmin {
+			if i == statsListLimit {
+				sb.WriteString(fmt.Sprintf("…и ещё %d\n", len(s.ByAdmin)-statsListLimit))
+				break
+			}
+			sb.WriteString(fmt.Sprintf(
+				"%d. %s — взято %d: ✅ %d, 🏁 %d, ❌ %d, ⏱ %s\n",
+				i+1,
+				adminLabel(a),
+				a.Taken,
+				a.Confirmed,
+				a.Completed,
+				a.Canceled,
+				formatDuration(a.AvgTakeTime),
+			))
+		}
+	}
+
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// HikeSummaryBlock renders the bookings and payments part of the hike card.
+func HikeSummaryBlock(s bookingService.HikeSummary) string {
+	var sb strings.Builder
+
+	sb.WriteString("📝 <b>Заявки</b>\n")
+
+	total := s.Total()
+	if total == 0 {
+		sb.WriteString("Заявок пока нет.\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("Всего: %d\n", total))
+		for _, status := range statsStatusOrder {
+			if n := s.ByStatus[status]; n > 0 {
+				sb.WriteString(fmt.Sprintf("%s: %d\n", statusLabel(status), n))
+			}
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n💵 Оплачено: %s GEL", money.Format(s.Revenue)))
+
+	return sb.String()
+}
+
+func StatsPeriodKeyboard(current bookingService.StatsPeriod) tgbot.InlineKeyboardMarkup {
+	button := func(period bookingService.StatsPeriod, text string) tgbot.InlineKeyboardButton {
+		if period == current {
+			text = "• " + text + " •"
+		}
+		return tgbot.NewInlineKeyboardButtonData(text, fmt.Sprintf("booking:stats:%s", period))
+	}
+
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			button(bookingService.PeriodWeek, "Неделя"),
+			button(bookingService.PeriodMonth, "Месяц"),
+			button(bookingService.PeriodAll, "Всё время"),
+		),
+	)
+}
+
+func statsPeriodLabel(s bookingService.Stats) string {
+	switch s.Period {
+	case bookingService.PeriodWeek:
+		return fmt.Sprintf("эта неделя (с %s)", s.Since.Format("02.01.2006"))
+	case bookingService.PeriodMonth:
+		return fmt.Sprintf("этот месяц (с %s)", s.Since.Format("02.01.2006"))
+	default:
+		return "всё время"
+	}
+}
+
+func adminLabel(a bookingService.AdminStats) string {
+	name := html.EscapeString(strings.TrimSpace(a.AdminName))
+	if name == "" {
+		name = "—"
+	}
+	if a.AdminUsername != "" {
+		name += " (@" + html.EscapeString(a.AdminUsername) + ")"
+	}
+	return name
+}
+
+func percent(part, total int64) string {
+	if total == 0 {
+		return "—"
+	}
+	return fmt.Sprintf("%d%%", part*100/total)
+}
+
+func formatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "меньше минуты"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d д %d ч", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%d ч %d мин", hours, minutes)
+	default:
+		return fmt.Sprintf("%d мин", minutes)
+	}
+}
@@ -1,15 +1,21 @@
 package common
 
-import tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
 
+// MainMenu is shown in the default language, the admin bot speaks only it.
 func MainMenu() tgbot.ReplyKeyboardMarkup {
+	lang := i18n.DefaultLang
+
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("🏔 Хайки"),
-			tgbot.NewKeyboardButton("📥 Заявки"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminHikes)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminBookings)),
 		),
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("❓ Помощь"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminHelp)),
 		),
 	)
 }
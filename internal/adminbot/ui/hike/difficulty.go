@@ -0,0 +1,165 @@
+package hike
+
+import (
+	"strings"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var difficultyLabels = map[trail.Difficulty]i18n.Key{
+	trail.DifficultyEasy:     i18n.AdminDifficultyEasy,
+	trail.DifficultyModerate: i18n.AdminDifficultyModerate,
+	trail.DifficultyHard:     i18n.AdminDifficultyHard,
+	trail.DifficultyExpert:   i18n.AdminDifficultyExpert,
+}
+
+var tagLabels = map[trail.Tag]i18n.Key{
+	trail.TagWaterfall: i18n.AdminTagWaterfall,
+	trail.TagMultiDay:  i18n.AdminTagMultiDay,
+	trail.TagSea:       i18n.AdminTagSea,
+	trail.TagFamily:    i18n.AdminTagFamily,
+}
+
+const (
+	TagsDoneButton  = "✅ Готово"
+	suggestedPrefix = "👍 "
+	selectedPrefix  = "☑️ "
+)
+
+func DifficultyLabel(d trail.Difficulty) string {
+	key, ok := difficultyLabels[d]
+	if !ok {
+		return string(d)
+	}
+	return i18n.T(i18n.DefaultLang, key)
+}
+
+func TagLabel(t trail.Tag) string {
+	key, ok := tagLabels[t]
+	if !ok {
+		return string(t)
+	}
+	return i18n.T(i18n.DefaultLang, key)
+}
+
+// TagsLabel lists the tags of a hike, "нет" for none.
+func TagsLabel(tags []trail.Tag) string {
+	if len(tags) == 0 {
+		return "нет"
+	}
+
+	labels := make([]string, 0, len(tags))
+	for _, t := range tags {
+		labels = append(labels, TagLabel(t))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// DifficultyByText reads the level from a keyboard button or a typed name
+// like «средний».
+func DifficultyByText(text string) (trail.Difficulty, bool) {
+	for _, d := range trail.Difficulties {
+		if labelMatches(text, DifficultyLabel(d), string(d)) {
+			return d, true
+		}
+	}
+	return "", false
+}
+
+// TagsByText reads the tags from a keyboard button or a typed list like
+// «водопад, море».
+func TagsByText(text string) ([]trail.Tag, bool) {
+	var tags []trail.Tag
+	for _, part := range strings.Split(text, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		found := false
+		for _, t := range trail.Tags {
+			if labelMatches(part, TagLabel(t), string(t)) {
+				tags = append(tags, t)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return tags, len(tags) > 0
+}
+
+// labelMatches compares the text with the label without its emoji, or with the code.
+func labelMatches(text, label, code string) bool {
+	text = strings.ToLower(strings.TrimSpace(text))
+	_, name, _ := strings.Cut(label, " ")
+
+	return text == code || strings.Contains(text, strings.ToLower(name))
+}
+
+// DifficultyKeyboard offers the levels of a new hike, the suggested one is
+// marked.
+func DifficultyKeyboard(suggested trail.Difficulty) tgbot.ReplyKeyboardMarkup {
+	levels := difficultyButtons(suggested)
+
+	return tgbot.NewReplyKeyboard(
+		levels[:2],
+		levels[2:],
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("⬅️ Назад"),
+		),
+	)
+}
+
+func EditDifficultyKeyboard() tgbot.ReplyKeyboardMarkup {
+	levels := difficultyButtons("")
+
+	return tgbot.NewReplyKeyboard(
+		levels[:2],
+		levels[2:],
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(i18n.DefaultLang, i18n.BtnAdminCancel)),
+		),
+	)
+}
+
+func difficultyButtons(suggested trail.Difficulty) []tgbot.KeyboardButton {
+	var buttons []tgbot.KeyboardButton
+	for _, d := range trail.Difficulties {
+		text := DifficultyLabel(d)
+		if d == suggested {
+			text = suggestedPrefix + text
+		}
+		buttons = append(buttons, tgbot.NewKeyboardButton(text))
+	}
+	return buttons
+}
+
+// TagsKeyboard toggles the tags of a new hike, the selected ones are marked.
+func TagsKeyboard(selected []trail.Tag) tgbot.ReplyKeyboardMarkup {
+	var buttons []tgbot.KeyboardButton
+	for _, t := range trail.Tags {
+		text := TagLabel(t)
+		for _, s := range selected {
+			if s == t {
+				text = selectedPrefix + text
+				break
+			}
+		}
+		buttons = append(buttons, tgbot.NewKeyboardButton(text))
+	}
+
+	return tgbot.NewReplyKeyboard(
+		buttons[:2],
+		buttons[2:],
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(TagsDoneButton),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("⬅️ Назад"),
+		),
+	)
+}
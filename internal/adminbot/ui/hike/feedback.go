@@ -0,0 +1,73 @@
+package hike
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+
+	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+)
+
+// feedbackCommentsLimit keeps the report well below the Telegram limit.
+const feedbackCommentsLimit = 15
+
+func FeedbackReport(title string, f hikeService.Feedback, loc *time.Location) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("⭐ <b>Отзывы о хайке</b>\n%s\n\n", html.EscapeString(title)))
+
+	if len(f.Reviews) == 0 {
+		sb.WriteString("Оценок пока нет.")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("Средняя оценка: <b>%.1f</b> из %d (оценок: %d)\n", f.Average, trail.MaxRating, len(f.Reviews)))
+	for stars := trail.MaxRating; stars >= trail.MinRating; stars-- {
+		sb.WriteString(fmt.Sprintf("%s — %d\n", strings.Repeat("⭐", stars), f.ByRating[stars-1]))
+	}
+
+	var commented []hikeService.Review
+	for _, r := range f.Reviews {
+		if r.Comment != "" {
+			commented = append(commented, r)
+		}
+	}
+
+	if len(commented) == 0 {
+		sb.WriteString("\nТекстовых отзывов нет.")
+		return sb.String()
+	}
+
+	sb.WriteString("\n💬 <b>Отзывы</b>\n")
+	for i, r := range commented {
+		if i == feedbackCommentsLimit {
+			sb.WriteString(fmt.Sprintf("\n…и ещё %d", len(commented)-feedbackCommentsLimit))
+			break
+		}
+
+		sb.WriteString(fmt.Sprintf(
+			"\n%s %s, заявка #%d, %s\n«%s»\n",
+			strings.Repeat("⭐", r.Rating),
+			reviewAuthor(r),
+			r.BookingID,
+			r.CreatedAt.In(loc).Format("02.01.2006"),
+			html.EscapeString(r.Comment),
+		))
+	}
+
+	return sb.String()
+}
+
+func reviewAuthor(r hikeService.Review) string {
+	name := html.EscapeString(strings.TrimSpace(r.UserName))
+	if name == "" {
+		name = "—"
+	}
+	if r.UserUsername != "" {
+		name += " (@" + html.EscapeString(r.UserUsername) + ")"
+	}
+	return name
+}
@@ -1,16 +1,23 @@
 package hike
 
-import tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"fmt"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
 
 func HikeMenu() tgbot.ReplyKeyboardMarkup {
+	lang := i18n.DefaultLang
+
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("➕ Создать хайк"),
-			tgbot.NewKeyboardButton("📋 Список хайков"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCreateHike)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminListHikes)),
 		),
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("❓ Помощь"),
-			tgbot.NewKeyboardButton("⬅️ Назад"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminHelp)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnBack)),
 		),
 	)
 }
@@ -25,44 +32,141 @@ func ConfirmKeyboard() tgbot.InlineKeyboardMarkup {
 }
 
 func SelectedHikeActionsKeyboard(isPublished bool) tgbot.ReplyKeyboardMarkup {
-	actionText := "📢 Опубликовать хайк"
+	lang := i18n.DefaultLang
+
+	action := i18n.BtnAdminPublishHike
 	if isPublished {
-		actionText = "🙈 Скрыть хайк"
+		action = i18n.BtnAdminHideHike
 	}
 
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton(actionText),
+			tgbot.NewKeyboardButton(i18n.T(lang, action)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminEditHike)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCloneHike)),
 		),
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("🧾 Карточка хайка"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminHikeCard)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminHikeRoster)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminHikeReviews)),
 		),
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("⬅️ Назад"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCancelHike)),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnBack)),
+		),
+	)
+}
+
+// HikeCardKeyboard holds the quick actions under the hike card, the callback
+// data is hike:card:<action>:<hike id>.
+func HikeCardKeyboard(hikeID int32, isPublished bool) tgbot.InlineKeyboardMarkup {
+	button := func(text, action string) tgbot.InlineKeyboardButton {
+		return tgbot.NewInlineKeyboardButtonData(text, fmt.Sprintf("hike:card:%s:%d", action, hikeID))
+	}
+
+	visibility := button("📢 Опубликовать", "publish")
+	if isPublished {
+		visibility = button("🙈 Скрыть", "hide")
+	}
+
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			visibility,
+			button("✏️ Редактировать", "edit"),
+		),
+		tgbot.NewInlineKeyboardRow(
+			button("📑 Дублировать", "clone"),
+			button("📋 Участники", "roster"),
 		),
 	)
 }
 
 func PublishConfirmKeyboard() tgbot.ReplyKeyboardMarkup {
+	return confirmKeyboard(i18n.BtnAdminConfirmPublish)
+}
+
+func HideConfirmKeyboard() tgbot.ReplyKeyboardMarkup {
+	return confirmKeyboard(i18n.BtnAdminConfirmHide)
+}
+
+func CancelHikeReasonKeyboard() tgbot.ReplyKeyboardMarkup {
+	lang := i18n.DefaultLang
+
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("✅ Да, опубликовать"),
-			tgbot.NewKeyboardButton("❌ Отмена"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCancelReasonWeather)),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCancel)),
 		),
 	)
 }
 
-func HideConfirmKeyboard() tgbot.ReplyKeyboardMarkup {
+func CancelHikeConfirmKeyboard() tgbot.ReplyKeyboardMarkup {
+	return confirmKeyboard(i18n.BtnAdminConfirmCancelHike)
+}
+
+// confirmKeyboard pairs the confirming button with the cancel one.
+func confirmKeyboard(confirm i18n.Key) tgbot.ReplyKeyboardMarkup {
+	lang := i18n.DefaultLang
+
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("✅ Да, скрыть"),
-			tgbot.NewKeyboardButton("❌ Отмена"),
+			tgbot.NewKeyboardButton(i18n.T(lang, confirm)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCancel)),
 		),
 	)
 }
 
 func CreateHikeKeyboard() tgbot.ReplyKeyboardMarkup {
 	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(i18n.DefaultLang, i18n.BtnBack)),
+		),
+	)
+}
+
+func TranslationKeyboard() tgbot.ReplyKeyboardMarkup {
+	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("⏭ Без перевода"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("⬅️ Назад"),
+		),
+	)
+}
+
+func PublishAtKeyboard() tgbot.ReplyKeyboardMarkup {
+	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("✋ Опубликую вручную"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("⬅️ Назад"),
+		),
+	)
+}
+
+func UnpublishAtKeyboard() tgbot.ReplyKeyboardMarkup {
+	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("♾ Не закрывать запись"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("⬅️ Назад"),
+		),
+	)
+}
+
+func ContinueDraftKeyboard() tgbot.ReplyKeyboardMarkup {
+	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("▶️ Продолжить черновик"),
+			tgbot.NewKeyboardButton("🗑 Начать заново"),
+		),
 		tgbot.NewKeyboardButtonRow(
 			tgbot.NewKeyboardButton("⬅️ Назад"),
 		),
@@ -73,10 +177,79 @@ func HikeConfirmMenu() tgbot.ReplyKeyboardMarkup {
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
 			tgbot.NewKeyboardButton("✅ Подтвердить"),
-			tgbot.NewKeyboardButton("❌ Отмена"),
+			tgbot.NewKeyboardButton(i18n.T(i18n.DefaultLang, i18n.BtnAdminCancel)),
 		),
 		tgbot.NewKeyboardButtonRow(
 			tgbot.NewKeyboardButton("⬅️ Назад"),
 		),
 	)
 }
+
+func CloneRepeatKeyboard() tgbot.ReplyKeyboardMarkup {
+	lang := i18n.DefaultLang
+
+	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCloneOnce)),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCloneWeekly)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCloneBiweekly)),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnAdminCancel)),
+		),
+	)
+}
+
+func CloneConfirmKeyboard() tgbot.ReplyKeyboardMarkup {
+	return confirmKeyboard(i18n.BtnAdminCloneConfirm)
+}
+
+func EditHikeFieldsKeyboard() tgbot.ReplyKeyboardMarkup {
+	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("🏔 Название"),
+			tgbot.NewKeyboardButton("🔎 Превью"),
+			tgbot.NewKeyboardButton("📝 Описание"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("💰 Цена"),
+			tgbot.NewKeyboardButton("📏 Длина"),
+			tgbot.NewKeyboardButton("⛰ Набор высоты"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("🎚 Сложность"),
+			tgbot.NewKeyboardButton("🏷 Теги"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("👥 Мест"),
+			tgbot.NewKeyboardButton("🗓 Даты"),
+			tgbot.NewKeyboardButton("📷 Фото"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("🇬🇧 Название"),
+			tgbot.NewKeyboardButton("🇬🇧 Превью"),
+			tgbot.NewKeyboardButton("🇬🇧 Описание"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton("📢 Публикация"),
+			tgbot.NewKeyboardButton("🔒 Закрытие записи"),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(i18n.DefaultLang, i18n.BtnAdminCancel)),
+		),
+	)
+}
+
+func EditHikeValueKeyboard() tgbot.ReplyKeyboardMarkup {
+	return tgbot.NewReplyKeyboard(
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButton(i18n.T(i18n.DefaultLang, i18n.BtnAdminCancel)),
+		),
+	)
+}
+
+func EditConfirmKeyboard() tgbot.ReplyKeyboardMarkup {
+	return confirmKeyboard(i18n.BtnAdminSave)
+}
@@ -0,0 +1,103 @@
+package hike
+
+import (
+	"fmt"
+	"time"
+
+	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/adminbot/hike/service"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// HikeListPageSize keeps the list keyboard short enough to fit on a phone screen.
+const HikeListPageSize = 8
+
+var hikeListFilters = []struct {
+	filter hikeService.HikeFilter
+	label  string
+}{
+	{hikeService.FilterAll, "Все"},
+	{hikeService.FilterPublished, "🟢 Опубл."},
+	{hikeService.FilterDraft, "📝 Черновики"},
+	{hikeService.FilterPast, "🏁 Прошедшие"},
+}
+
+func HikeListMessage(p hikeService.HikePage) string {
+	if len(p.Hikes) == 0 {
+		return "🏔 Список хайков\n\nПо этому фильтру хайков нет."
+	}
+
+	return fmt.Sprintf(
+		"🏔 Список хайков — %s, страница %d из %d\n\nВыберите хайк, чтобы открыть действия.",
+		hikeListFilterLabel(p.Filter),
+		p.Page,
+		p.Pages,
+	)
+}
+
+// HikeListKeyboard lists the page of hikes with filters and paging, the
+// callback data is hike:open:<hike id> and hike:list:<filter>:<page>, buttons
+// that would not change the list send hike:noop.
+func HikeListKeyboard(p hikeService.HikePage, loc *time.Location) tgbot.InlineKeyboardMarkup {
+	var rows [][]tgbot.InlineKeyboardButton
+
+	for _, h := range p.Hikes {
+		rows = append(rows, tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(
+				fmt.Sprintf("%s %s · %s", hikeListStatus(h), h.StartsAt.In(loc).Format("02.01"), h.TitleRu),
+				fmt.Sprintf("hike:open:%d", h.ID),
+			),
+		))
+	}
+
+	if p.Pages > 1 {
+		var nav []tgbot.InlineKeyboardButton
+		if p.HasPrev() {
+			nav = append(nav, tgbot.NewInlineKeyboardButtonData("◀️", hikeListData(p.Filter, p.Page-1)))
+		}
+		nav = append(nav, tgbot.NewInlineKeyboardButtonData(fmt.Sprintf("%d / %d", p.Page, p.Pages), "hike:noop"))
+		if p.HasNext() {
+			nav = append(nav, tgbot.NewInlineKeyboardButtonData("▶️", hikeListData(p.Filter, p.Page+1)))
+		}
+		rows = append(rows, nav)
+	}
+
+	var filters []tgbot.InlineKeyboardButton
+	for _, f := range hikeListFilters {
+		if f.filter == p.Filter {
+			filters = append(filters, tgbot.NewInlineKeyboardButtonData("• "+f.label+" •", "hike:noop"))
+			continue
+		}
+		filters = append(filters, tgbot.NewInlineKeyboardButtonData(f.label, hikeListData(f.filter, 1)))
+	}
+	rows = append(rows, filters[:2], filters[2:])
+
+	return tgbot.NewInlineKeyboardMarkup(rows...)
+}
+
+func hikeListData(filter hikeService.HikeFilter, page int32) string {
+	return fmt.Sprintf("hike:list:%s:%d", filter, page)
+}
+
+func hikeListStatus(h hikeService.Hike) string {
+	switch {
+	case h.IsPublished:
+		return "✅"
+	case h.PublishAt != nil:
+		return "⏰"
+	default:
+		return "📝"
+	}
+}
+
+func hikeListFilterLabel(filter hikeService.HikeFilter) string {
+	switch filter {
+	case hikeService.FilterPublished:
+		return "опубликованные"
+	case hikeService.FilterDraft:
+		return "черновики"
+	case hikeService.FilterPast:
+		return "прошедшие"
+	default:
+		return "все"
+	}
+}
@@ -23,6 +23,7 @@ func (r *repository) UpsertTelegramUser(ctx context.Context, tgUser service.Tele
 		TgUserID:   tgUser.TgUserID,
 		TgUsername: toPgText(tgUser.TgUsername),
 		FullName:   toPgText(tgUser.FullName),
+		Lang:       tgUser.Lang,
 	})
 	if err != nil {
 		return 0, logger.WrapError(err)
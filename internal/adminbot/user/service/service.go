@@ -1,12 +1,17 @@
 package service
 
-import "context"
+import (
+	"context"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+)
 
 type TelegramUser struct {
 	ID         int32
 	TgUserID   int64
 	TgUsername string
 	FullName   string
+	Lang       string
 }
 
 type Repository interface {
@@ -14,6 +19,8 @@ type Repository interface {
 }
 
 type Service interface {
+	// EnsureTelegramUser creates the user or refreshes their name. tgUser.Lang
+	// is only stored for a new user, the language chosen in the client bot is kept.
 	EnsureTelegramUser(ctx context.Context, tgUser TelegramUser) (int32, error)
 }
 
@@ -26,5 +33,8 @@ func New(r Repository) Service {
 }
 
 func (s *service) EnsureTelegramUser(ctx context.Context, tgUser TelegramUser) (int32, error) {
+	if !i18n.Supported(tgUser.Lang) {
+		tgUser.Lang = i18n.DefaultLang
+	}
 	return s.repo.UpsertTelegramUser(ctx, tgUser)
 }
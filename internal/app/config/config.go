@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"time"
 )
 
 type Common struct {
@@ -16,12 +17,18 @@ type Common struct {
 
 type AdminBot struct {
 	Common
-	AdminBotToken string
+	AdminBotToken  string
+	ClientBotToken string
+	// BookingEscalateAfter is how long a new booking may wait before the
+	// admin chat is reminded about it, BookingExpireAfter - before it is closed.
+	BookingEscalateAfter time.Duration
+	BookingExpireAfter   time.Duration
 }
 
 type ClientBot struct {
 	Common
 	ClientBotToken string
+	AdminBotToken  string
 	AdminBotName   string
 }
 
@@ -38,8 +45,12 @@ func MustLoadCommon() Common {
 func MustLoadAdminBot() AdminBot {
 	common := MustLoadCommon()
 	return AdminBot{
-		Common:        common,
-		AdminBotToken: getenv("ADMIN_BOT_TOKEN"),
+		Common:         common,
+		AdminBotToken:  getenv("ADMIN_BOT_TOKEN"),
+		ClientBotToken: getenv("CLIENT_BOT_TOKEN"),
+
+		BookingEscalateAfter: durationOr("BOOKING_ESCALATE_AFTER", 6*time.Hour),
+		BookingExpireAfter:   durationOr("BOOKING_EXPIRE_AFTER", 48*time.Hour),
 	}
 }
 
@@ -48,6 +59,7 @@ func MustLoadClientBot() ClientBot {
 	return ClientBot{
 		Common:         common,
 		ClientBotToken: getenv("CLIENT_BOT_TOKEN"),
+		AdminBotToken:  getenv("ADMIN_BOT_TOKEN"),
 		AdminBotName:   getenv("ADMIN_BOT_NAME"),
 	}
 }
@@ -60,6 +72,20 @@ func getenv(k string) string {
 	return v
 }
 
+// durationOr parses a duration like "6h" or "90m", def is used when the env is not set.
+func durationOr(k string, def time.Duration) time.Duration {
+	v := os.Getenv(k)
+	if v == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Fatalf("bad duration in env %s: %q", k, v)
+	}
+	return d
+}
+
 func mustParseInt64(s string) int64 {
 	i, err := strconv.Atoi(s)
 	if err != nil {
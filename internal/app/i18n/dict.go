@@ -1 +1,150 @@
-package i18n
\ No newline at end of file
+// Package i18n is the message catalog of both bots. Texts are kept per
+// language in locales/<lang>.json and looked up by Key. A key missing in a
+// language falls back to DefaultLang, so texts of the admin bot, which only
+// speaks Russian, exist in ru.json only.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+)
+
+// DefaultLang is used for users with an unknown language and for keys
+// missing a translation.
+const DefaultLang = "ru"
+
+// langEN is the only language hikes can be translated to.
+const langEN = "en"
+
+// buttonPrefix marks the keys of keyboard buttons, KeyOf resolves only them.
+const buttonPrefix = "btn."
+
+//go:embed locales/*.json
+var locales embed.FS
+
+type dictionary struct {
+	texts map[string]map[Key]string
+	// buttons maps a button text in any language back to its key
+	buttons map[string]Key
+}
+
+var dict = mustLoad(locales)
+
+// T returns the text of the key in the language. Arguments are formatted
+// into the text with fmt.Sprintf. An unknown key is returned as is, so a
+// missing text is visible but doesn't break the bot.
+func T(lang string, key Key, args ...any) string {
+	text, ok := dict.texts[lang][key]
+	if !ok {
+		text, ok = dict.texts[DefaultLang][key]
+	}
+	if !ok {
+		return string(key)
+	}
+
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// KeyOf resolves the text of a pressed keyboard button to its key. Buttons
+// of every language are recognized.
+func KeyOf(text string) (Key, bool) {
+	key, ok := dict.buttons[text]
+	return key, ok
+}
+
+// Langs returns the languages of the catalog, DefaultLang first.
+func Langs() []string {
+	langs := make([]string, 0, len(dict.texts))
+	for lang := range dict.texts {
+		if lang != DefaultLang {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs)
+
+	return append([]string{DefaultLang}, langs...)
+}
+
+// Supported reports whether the catalog has texts in the language.
+func Supported(lang string) bool {
+	_, ok := dict.texts[lang]
+	return ok
+}
+
+// Match picks the catalog language for the IETF language tag Telegram
+// reports for a user, e.g. "en-US" gives "en". Unknown and empty tags
+// give DefaultLang.
+func Match(tag string) string {
+	base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+	if Supported(base) {
+		return base
+	}
+	return DefaultLang
+}
+
+// Localized picks a hike text written by the admins: the English version
+// for English users if there is one, the Russian original otherwise.
+func Localized(lang, ru, en string) string {
+	if lang == langEN && en != "" {
+		return en
+	}
+	return ru
+}
+
+func mustLoad(fsys fs.FS) dictionary {
+	d, err := load(fsys)
+	if err != nil {
+		panic(fmt.Sprintf("i18n: %v", err))
+	}
+	return d
+}
+
+func load(fsys fs.FS) (dictionary, error) {
+	paths, err := fs.Glob(fsys, "locales/*.json")
+	if err != nil {
+		return dictionary{}, err
+	}
+
+	d := dictionary{
+		texts:   make(map[string]map[Key]string, len(paths)),
+		buttons: make(map[string]Key),
+	}
+
+	for _, p := range paths {
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return dictionary{}, err
+		}
+
+		var texts map[Key]string
+		if err := json.Unmarshal(data, &texts); err != nil {
+			return dictionary{}, fmt.Errorf("%s: %w", p, err)
+		}
+
+		for key, text := range texts {
+			if !strings.HasPrefix(string(key), buttonPrefix) {
+				continue
+			}
+			if other, ok := d.buttons[text]; ok && other != key {
+				return dictionary{}, fmt.Errorf("%s: buttons %q and %q share the text %q", p, other, key, text)
+			}
+			d.buttons[text] = key
+		}
+
+		d.texts[strings.TrimSuffix(path.Base(p), ".json")] = texts
+	}
+
+	if _, ok := d.texts[DefaultLang]; !ok {
+		return dictionary{}, fmt.Errorf("no texts for the default language %q", DefaultLang)
+	}
+
+	return d, nil
+}
@@ -0,0 +1,180 @@
+package i18n
+
+// Key identifies a text in the catalog. Keys of keyboard buttons start with
+// "btn." and can be resolved back from the button text with KeyOf.
+type Key string
+
+// Shared
+const (
+	BtnBack  Key = "btn.back"
+	BtnAbort Key = "btn.abort"
+	LangName Key = "lang.name"
+)
+
+// Client bot
+const (
+	BtnClientHikes              Key = "btn.client.hikes"
+	BtnClientMyBookings         Key = "btn.client.my_bookings"
+	BtnClientHelp               Key = "btn.client.help"
+	BtnClientBookHike           Key = "btn.client.book_hike"
+	BtnClientHikeDetails        Key = "btn.client.hike_details"
+	BtnClientSettings           Key = "btn.client.settings"
+	BtnClientSharePhone         Key = "btn.client.share_phone"
+	BtnClientBookingSent        Key = "btn.client.booking_sent"
+	BtnClientBookingWaitlisted  Key = "btn.client.booking_waitlisted"
+	BtnClientBookingCancel      Key = "btn.client.booking_cancel"
+	BtnClientBookingPay         Key = "btn.client.booking_pay"
+	BtnClientCancelReasonPlans  Key = "btn.client.cancel_reason.plans"
+	BtnClientCancelReasonDates  Key = "btn.client.cancel_reason.dates"
+	BtnClientCancelReasonHealth Key = "btn.client.cancel_reason.health"
+	BtnClientCancelReasonPrice  Key = "btn.client.cancel_reason.price"
+	BtnClientCancelReasonOther  Key = "btn.client.cancel_reason.other"
+	BtnClientCancelKeep         Key = "btn.client.cancel_keep"
+	BtnClientReviewWrite        Key = "btn.client.review_write"
+	BtnClientReviewLater        Key = "btn.client.review_later"
+	BtnClientCatalogAllMonths   Key = "btn.client.catalog_all_months"
+	BtnClientCatalogFilters     Key = "btn.client.catalog_filters"
+	BtnClientFilterDates        Key = "btn.client.filter.dates"
+	BtnClientFilterPrice        Key = "btn.client.filter.price"
+	BtnClientFilterQuery        Key = "btn.client.filter.query"
+	BtnClientFilterReset        Key = "btn.client.filter.reset"
+	BtnClientFilterShow         Key = "btn.client.filter.show"
+	BtnClientHikeChangeKeep     Key = "btn.client.hike_change_keep"
+	BtnClientHikeChangeCancel   Key = "btn.client.hike_change_cancel"
+
+	ClientChooseSection              Key = "client.choose_section"
+	ClientHelp                       Key = "client.help"
+	ClientHikesEmpty                 Key = "client.hikes.empty"
+	ClientCatalogMonthEmpty          Key = "client.catalog.month_empty"
+	ClientCatalogNothingFound        Key = "client.catalog.nothing_found"
+	ClientDifficultyEasy             Key = "client.difficulty.easy"
+	ClientDifficultyModerate         Key = "client.difficulty.moderate"
+	ClientDifficultyHard             Key = "client.difficulty.hard"
+	ClientDifficultyExpert           Key = "client.difficulty.expert"
+	ClientTagWaterfall               Key = "client.tag.waterfall"
+	ClientTagMultiDay                Key = "client.tag.multi_day"
+	ClientTagSea                     Key = "client.tag.sea"
+	ClientTagFamily                  Key = "client.tag.family"
+	ClientFilters                    Key = "client.filters"
+	ClientFilterAny                  Key = "client.filter.any"
+	ClientFilterPriceValue           Key = "client.filter.price_value"
+	ClientFilterDatesPrompt          Key = "client.filter.dates_prompt"
+	ClientFilterPricePrompt          Key = "client.filter.price_prompt"
+	ClientFilterQueryPrompt          Key = "client.filter.query_prompt"
+	ClientFilterBadDates             Key = "client.filter.bad_dates"
+	ClientFilterBadPrice             Key = "client.filter.bad_price"
+	ClientHikeDistance               Key = "client.hike.distance"
+	ClientHikeElevationGain          Key = "client.hike.elevation_gain"
+	ClientError                      Key = "client.error"
+	ClientLayoutDate                 Key = "client.layout.date"
+	ClientLayoutDateTime             Key = "client.layout.date_time"
+	ClientLayoutShortDate            Key = "client.layout.short_date"
+	ClientLayoutMonth                Key = "client.layout.month"
+	ClientSettings                   Key = "client.settings"
+	ClientLangChanged                Key = "client.lang.changed"
+	ClientPhoneSaved                 Key = "client.phone.saved"
+	ClientPhoneNotOwn                Key = "client.phone.not_own"
+	ClientBookingBadRequest          Key = "client.booking.bad_request"
+	ClientBookingHikeUnavailable     Key = "client.booking.hike_unavailable"
+	ClientBookingSent                Key = "client.booking.sent"
+	ClientBookingRebooked            Key = "client.booking.rebooked"
+	ClientBookingAlreadySent         Key = "client.booking.already_sent"
+	ClientBookingWaitlisted          Key = "client.booking.waitlisted"
+	ClientBookingAlreadyWaitlisted   Key = "client.booking.already_waitlisted"
+	ClientBookingAlreadyConfirmed    Key = "client.booking.already_confirmed"
+	ClientBookingAlreadyBooked       Key = "client.booking.already_booked"
+	ClientBookingAlreadyBookedStatus Key = "client.booking.already_booked_status"
+	ClientBookingStatusNew           Key = "client.booking.status.new"
+	ClientBookingStatusInProgress    Key = "client.booking.status.in_progress"
+	ClientBookingStatusConfirmed     Key = "client.booking.status.confirmed"
+	ClientBookingStatusCompleted     Key = "client.booking.status.completed"
+	ClientBookingStatusCanceled      Key = "client.booking.status.canceled"
+	ClientBookingStatusWaitlisted    Key = "client.booking.status.waitlisted"
+	ClientMyBookingsTitle            Key = "client.my_bookings.title"
+	ClientMyBookingsEmpty            Key = "client.my_bookings.empty"
+	ClientMyBookingsUpcoming         Key = "client.my_bookings.upcoming"
+	ClientMyBookingsPast             Key = "client.my_bookings.past"
+	ClientMyBookingsLoadFailed       Key = "client.my_bookings.load_failed"
+	ClientCancelNone                 Key = "client.cancel.none"
+	ClientCancelChoose               Key = "client.cancel.choose"
+	ClientCancelAskReason            Key = "client.cancel.ask_reason"
+	ClientCancelTypeReason           Key = "client.cancel.type_reason"
+	ClientCancelReasonAsText         Key = "client.cancel.reason_as_text"
+	ClientCancelKept                 Key = "client.cancel.kept"
+	ClientCancelDone                 Key = "client.cancel.done"
+	ClientCancelNotAllowed           Key = "client.cancel.not_allowed"
+	ClientHikeChangeKept             Key = "client.hike_change.kept"
+	ClientReceiptPrompt              Key = "client.receipt.prompt"
+	ClientReceiptLater               Key = "client.receipt.later"
+	ClientReceiptBadAmount           Key = "client.receipt.bad_amount"
+	ClientReceiptSubmitted           Key = "client.receipt.submitted"
+	ClientReceiptSaved               Key = "client.receipt.saved"
+	ClientReceiptNotAllowed          Key = "client.receipt.not_allowed"
+	ClientReceiptFailed              Key = "client.receipt.failed"
+	ClientReviewNotAllowed           Key = "client.review.not_allowed"
+	ClientReviewRateFailed           Key = "client.review.rate_failed"
+	ClientReviewRated                Key = "client.review.rated"
+	ClientReviewCommentPrompt        Key = "client.review.comment_prompt"
+	ClientReviewSkipped              Key = "client.review.skipped"
+	ClientReviewSaved                Key = "client.review.saved"
+	ClientReviewNotRated             Key = "client.review.not_rated"
+	ClientReviewFailed               Key = "client.review.failed"
+	ClientReviewRating               Key = "client.review.rating"
+	ClientReviewRecent               Key = "client.review.recent"
+	ClientReviewAnonymous            Key = "client.review.anonymous"
+)
+
+// Client notifications, sent by both bots. The texts are html/template
+// templates rendered by package notify.
+const (
+	NotifyBookingTaken     Key = "notify.booking_taken"
+	NotifyBookingConfirmed Key = "notify.booking_confirmed"
+	NotifyBookingCanceled  Key = "notify.booking_canceled"
+	NotifyBookingCompleted Key = "notify.booking_completed"
+	NotifyBookingExpired   Key = "notify.booking_expired"
+	NotifyWaitlistPromoted Key = "notify.waitlist_promoted"
+	NotifyHikeReminder48h  Key = "notify.hike_reminder_48h"
+	NotifyHikeReminder3h   Key = "notify.hike_reminder_3h"
+	NotifyHikeCanceled     Key = "notify.hike_canceled"
+	NotifyHikeChanged      Key = "notify.hike_changed"
+)
+
+// Admin bot
+const (
+	BtnAdminHikes               Key = "btn.admin.hikes"
+	BtnAdminBookings            Key = "btn.admin.bookings"
+	BtnAdminHelp                Key = "btn.admin.help"
+	BtnAdminCreateHike          Key = "btn.admin.create_hike"
+	BtnAdminListHikes           Key = "btn.admin.list_hikes"
+	BtnAdminListBookings        Key = "btn.admin.list_bookings"
+	BtnAdminBookingStats        Key = "btn.admin.booking_stats"
+	BtnAdminPublishHike         Key = "btn.admin.publish_hike"
+	BtnAdminHideHike            Key = "btn.admin.hide_hike"
+	BtnAdminEditHike            Key = "btn.admin.edit_hike"
+	BtnAdminCloneHike           Key = "btn.admin.clone_hike"
+	BtnAdminHikeCard            Key = "btn.admin.hike_card"
+	BtnAdminHikeRoster          Key = "btn.admin.hike_roster"
+	BtnAdminHikeReviews         Key = "btn.admin.hike_reviews"
+	BtnAdminCancelHike          Key = "btn.admin.cancel_hike"
+	BtnAdminConfirmPublish      Key = "btn.admin.confirm_publish"
+	BtnAdminConfirmHide         Key = "btn.admin.confirm_hide"
+	BtnAdminConfirmCancelHike   Key = "btn.admin.confirm_cancel_hike"
+	BtnAdminCancelReasonWeather Key = "btn.admin.cancel_reason.weather"
+	BtnAdminCloneOnce           Key = "btn.admin.clone_once"
+	BtnAdminCloneWeekly         Key = "btn.admin.clone_weekly"
+	BtnAdminCloneBiweekly       Key = "btn.admin.clone_biweekly"
+	BtnAdminCloneConfirm        Key = "btn.admin.clone_confirm"
+	BtnAdminSave                Key = "btn.admin.save"
+	BtnAdminCancel              Key = "btn.admin.cancel"
+
+	AdminChooseSection      Key = "admin.choose_section"
+	AdminHelp               Key = "admin.help"
+	AdminDifficultyEasy     Key = "admin.difficulty.easy"
+	AdminDifficultyModerate Key = "admin.difficulty.moderate"
+	AdminDifficultyHard     Key = "admin.difficulty.hard"
+	AdminDifficultyExpert   Key = "admin.difficulty.expert"
+	AdminTagWaterfall       Key = "admin.tag.waterfall"
+	AdminTagMultiDay        Key = "admin.tag.multi_day"
+	AdminTagSea             Key = "admin.tag.sea"
+	AdminTagFamily          Key = "admin.tag.family"
+)
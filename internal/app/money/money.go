@@ -0,0 +1,12 @@
+// Package money formats payment amounts the same way in both bots.
+package money
+
+import "strconv"
+
+// Format drops the decimal part of whole amounts: 150, 150.50.
+func Format(amount float64) string {
+	if amount == float64(int64(amount)) {
+		return strconv.FormatInt(int64(amount), 10)
+	}
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"fmt"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// RatingKeyboard asks the client to rate a completed hike. The buttons are
+// handled by the client bot as "review_rate:<booking_id>:<stars>".
+func RatingKeyboard(bookingID int32) tgbot.InlineKeyboardMarkup {
+	row := make([]tgbot.InlineKeyboardButton, 0, trail.MaxRating)
+	for stars := trail.MinRating; stars <= trail.MaxRating; stars++ {
+		row = append(row, tgbot.NewInlineKeyboardButtonData(
+			fmt.Sprintf("%d ⭐", stars),
+			fmt.Sprintf("review_rate:%d:%d", bookingID, stars),
+		))
+	}
+
+	return tgbot.NewInlineKeyboardMarkup(row)
+}
+
+// HikeChangedKeyboard lets the client keep or cancel the booking after the
+// hike has changed. The buttons are handled by the client bot as
+// "hike_change_keep:<booking_id>" and "hike_change_cancel:<booking_id>".
+func HikeChangedKeyboard(lang string, bookingID int32) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientHikeChangeKeep), fmt.Sprintf("hike_change_keep:%d", bookingID)),
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientHikeChangeCancel), fmt.Sprintf("hike_change_cancel:%d", bookingID)),
+		),
+	)
+}
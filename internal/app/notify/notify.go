@@ -0,0 +1,148 @@
+// Package notify sends client notifications through the client bot. It is
+// shared by both bots, so the admin process can reach clients who never
+// talked to the admin bot. The templates are texts of the i18n catalog.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+type Template string
+
+const (
+	BookingTaken     Template = "booking_taken"
+	BookingConfirmed Template = "booking_confirmed"
+	BookingCanceled  Template = "booking_canceled"
+	BookingCompleted Template = "booking_completed"
+	BookingExpired   Template = "booking_expired"
+	WaitlistPromoted Template = "waitlist_promoted"
+	HikeReminder48h  Template = "hike_reminder_48h"
+	HikeReminder3h   Template = "hike_reminder_3h"
+	HikeCanceled     Template = "hike_canceled"
+	HikeChanged      Template = "hike_changed"
+)
+
+var templateKeys = map[Template]i18n.Key{
+	BookingTaken:     i18n.NotifyBookingTaken,
+	BookingConfirmed: i18n.NotifyBookingConfirmed,
+	BookingCanceled:  i18n.NotifyBookingCanceled,
+	BookingCompleted: i18n.NotifyBookingCompleted,
+	BookingExpired:   i18n.NotifyBookingExpired,
+	WaitlistPromoted: i18n.NotifyWaitlistPromoted,
+	HikeReminder48h:  i18n.NotifyHikeReminder48h,
+	HikeReminder3h:   i18n.NotifyHikeReminder3h,
+	HikeCanceled:     i18n.NotifyHikeCanceled,
+	HikeChanged:      i18n.NotifyHikeChanged,
+}
+
+// Booking is the data of the booking templates.
+type Booking struct {
+	ID              int32
+	HikeTitle       string
+	HikeStartsAt    time.Time
+	ManagerName     string
+	ManagerUsername string
+	// Reason is set for HikeCanceled.
+	Reason string
+}
+
+// HikeUpdate is the data of the HikeChanged template.
+type HikeUpdate struct {
+	HikeTitle string
+	Changes   []Change
+}
+
+// Change is one changed hike field. Field is one of "title", "starts_at",
+// "ends_at" or "price", Old and New hold values of the field type.
+type Change struct {
+	Field string
+	Old   any
+	New   any
+}
+
+type Service interface {
+	// Send renders the template in the user's language and sends it to the chat.
+	Send(ctx context.Context, chatID int64, lang string, t Template, data any) error
+	// SendWithKeyboard is Send with an inline keyboard under the message.
+	SendWithKeyboard(ctx context.Context, chatID int64, lang string, t Template, data any, kb tgbot.InlineKeyboardMarkup) error
+}
+
+type service struct {
+	clientBot *tgbot.BotAPI
+	templates map[string]map[Template]*template.Template
+}
+
+// New renders dates of the templates in loc, the timezone of the hikes.
+func New(clientBot *tgbot.BotAPI, loc *time.Location) Service {
+	langs := i18n.Langs()
+	s := &service{
+		clientBot: clientBot,
+		templates: make(map[string]map[Template]*template.Template, len(langs)),
+	}
+
+	for _, lang := range langs {
+		layout := i18n.T(lang, i18n.ClientLayoutDateTime)
+		funcs := template.FuncMap{
+			"date": func(t time.Time) string { return t.In(loc).Format(layout) },
+		}
+
+		s.templates[lang] = make(map[Template]*template.Template, len(templateKeys))
+		for name, key := range templateKeys {
+			s.templates[lang][name] = template.Must(
+				template.New(string(name)).Funcs(funcs).Parse(i18n.T(lang, key)),
+			)
+		}
+	}
+
+	return s
+}
+
+func (s *service) Send(ctx context.Context, chatID int64, lang string, t Template, data any) error {
+	return s.send(chatID, lang, t, data, nil)
+}
+
+func (s *service) SendWithKeyboard(ctx context.Context, chatID int64, lang string, t Template, data any, kb tgbot.InlineKeyboardMarkup) error {
+	return s.send(chatID, lang, t, data, kb)
+}
+
+func (s *service) send(chatID int64, lang string, t Template, data any, markup any) error {
+	text, err := s.render(lang, t, data)
+	if err != nil {
+		return err
+	}
+
+	msg := tgbot.NewMessage(chatID, text)
+	msg.ParseMode = tgbot.ModeHTML
+	msg.ReplyMarkup = markup
+
+	if _, err := s.clientBot.Send(msg); err != nil {
+		return logger.WrapError(fmt.Errorf("failed to send %s to chat=%d: %w", t, chatID, err))
+	}
+
+	return nil
+}
+
+func (s *service) render(lang string, t Template, data any) (string, error) {
+	tpl, ok := s.templates[lang][t]
+	if !ok {
+		tpl, ok = s.templates[i18n.DefaultLang][t]
+	}
+	if !ok {
+		return "", logger.WrapError(fmt.Errorf("unknown notification template %q", t))
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", logger.WrapError(err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,73 @@
+// Package scheduler runs periodic background jobs of a bot process.
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+)
+
+// Job gets the current time in the configured location, so jobs don't
+// depend on the process time zone.
+type Job func(ctx context.Context, now time.Time) error
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      Job
+}
+
+type Scheduler struct {
+	loc  *time.Location
+	log  logger.Logger
+	jobs []job
+}
+
+func New(loc *time.Location, log logger.Logger) *Scheduler {
+	return &Scheduler{loc: loc, log: log}
+}
+
+// Every registers a job to run at start and then every interval.
+// Jobs must be registered before Run.
+func (s *Scheduler) Every(name string, interval time.Duration, j Job) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: j})
+}
+
+// Run blocks until ctx is done. Each job runs in its own goroutine, a run
+// never overlaps with the previous run of the same job.
+func (s *Scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, j := range s.jobs {
+		wg.Add(1)
+		go func(j job) {
+			defer wg.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, j)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, j job) {
+	if err := j.run(ctx, time.Now().In(s.loc)); err != nil {
+		s.log.StructuredError("scheduler: "+j.name+" failed", err)
+	}
+}
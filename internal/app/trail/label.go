@@ -0,0 +1,35 @@
+package trail
+
+import "github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+
+// The labels are the ones clients see in the catalog. The admin bot uses
+// them to count the length of client captions.
+var difficultyLabels = map[Difficulty]i18n.Key{
+	DifficultyEasy:     i18n.ClientDifficultyEasy,
+	DifficultyModerate: i18n.ClientDifficultyModerate,
+	DifficultyHard:     i18n.ClientDifficultyHard,
+	DifficultyExpert:   i18n.ClientDifficultyExpert,
+}
+
+var tagLabels = map[Tag]i18n.Key{
+	TagWaterfall: i18n.ClientTagWaterfall,
+	TagMultiDay:  i18n.ClientTagMultiDay,
+	TagSea:       i18n.ClientTagSea,
+	TagFamily:    i18n.ClientTagFamily,
+}
+
+func DifficultyLabel(d Difficulty, lang string) string {
+	key, ok := difficultyLabels[d]
+	if !ok {
+		return string(d)
+	}
+	return i18n.T(lang, key)
+}
+
+func TagLabel(t Tag, lang string) string {
+	key, ok := tagLabels[t]
+	if !ok {
+		return string(t)
+	}
+	return i18n.T(lang, key)
+}
@@ -0,0 +1,101 @@
+// Package trail describes hike routes the same way for both bots: the
+// difficulty levels, the tags clients filter the catalog by and the
+// rating scale of hike reviews.
+package trail
+
+// Difficulty is chosen by the organisers when they create the hike.
+type Difficulty string
+
+const (
+	DifficultyEasy     Difficulty = "easy"
+	DifficultyModerate Difficulty = "moderate"
+	DifficultyHard     Difficulty = "hard"
+	DifficultyExpert   Difficulty = "expert"
+)
+
+var Difficulties = []Difficulty{
+	DifficultyEasy,
+	DifficultyModerate,
+	DifficultyHard,
+	DifficultyExpert,
+}
+
+func (d Difficulty) Valid() bool {
+	for _, level := range Difficulties {
+		if level == d {
+			return true
+		}
+	}
+	return false
+}
+
+// difficultyEfforts holds the lowest effort of every level but the first,
+// migration 022 used the same values for the hikes created before.
+var difficultyEfforts = map[Difficulty]float64{
+	DifficultyModerate: 10,
+	DifficultyHard:     18,
+	DifficultyExpert:   26,
+}
+
+// SuggestDifficulty derives the level of a route from its length and
+// elevation gain, every 100 m of gain counts as one more kilometre.
+func SuggestDifficulty(distanceKm float64, elevationGainM int) Difficulty {
+	effort := distanceKm + float64(elevationGainM)/100
+	for i := len(Difficulties) - 1; i > 0; i-- {
+		if effort >= difficultyEfforts[Difficulties[i]] {
+			return Difficulties[i]
+		}
+	}
+	return DifficultyEasy
+}
+
+// Tag marks a feature of the hike clients can filter the catalog by.
+type Tag string
+
+const (
+	TagWaterfall Tag = "waterfall"
+	TagMultiDay  Tag = "multi-day"
+	TagSea       Tag = "sea"
+	TagFamily    Tag = "family"
+)
+
+var Tags = []Tag{
+	TagWaterfall,
+	TagMultiDay,
+	TagSea,
+	TagFamily,
+}
+
+func (t Tag) Valid() bool {
+	for _, tag := range Tags {
+		if tag == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTags reads the tags column of a hike.
+func ParseTags(raw []string) []Tag {
+	tags := make([]Tag, 0, len(raw))
+	for _, t := range raw {
+		tags = append(tags, Tag(t))
+	}
+	return tags
+}
+
+// TagStrings is the value of the tags column. It never returns nil, the
+// column is NOT NULL.
+func TagStrings(tags []Tag) []string {
+	raw := make([]string, 0, len(tags))
+	for _, t := range tags {
+		raw = append(raw, string(t))
+	}
+	return raw
+}
+
+// Clients rate a completed hike with MinRating to MaxRating stars.
+const (
+	MinRating = 1
+	MaxRating = 5
+)
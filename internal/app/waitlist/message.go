@@ -0,0 +1,47 @@
+package waitlist
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func adminMessage(b client.GetBookingDetailsRow, loc *time.Location) string {
+	clientName := html.EscapeString(strings.TrimSpace(b.UserName))
+	if clientName == "" {
+		clientName = "—"
+	}
+
+	unameLine := "—"
+	if strings.TrimSpace(b.UserUsername) != "" {
+		unameLine = "@" + html.EscapeString(b.UserUsername)
+	}
+
+	return fmt.Sprintf(
+		"🆕 <b>Заявка из листа ожидания</b>\n\n"+
+			"📦 ID заявки: %d\n"+
+			"📍 Хайк: %s\n"+
+			"🗓 Дата: %s\n\n"+
+			"Данные клиента\n"+
+			"🔗 Username: %s\n"+
+			"👤 Пользователь: <a href=\"tg://user?id=%d\">%s</a>\n",
+		b.ID,
+		html.EscapeString(b.HikeTitle),
+		b.HikeStartsAt.In(loc).Format("02.01.2006 15:04"),
+		unameLine,
+		b.UserTgID,
+		clientName,
+	)
+}
+
+func takeKeyboard(bookingID int32) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData("🟢 Взять в работу", fmt.Sprintf("booking_take:%d", bookingID)),
+		),
+	)
+}
@@ -0,0 +1,153 @@
+// Package waitlist moves waitlisted bookings into free seats of a hike.
+// Seats are freed in both bots: a client cancels, a manager cancels or the
+// booking expires, an admin raises the hike capacity. The promoted bookings
+// are posted to the admin chat through the client bot, which handles their
+// booking_take buttons.
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/notify"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Service interface {
+	// Promote moves waitlisted bookings of the hike into its free seats,
+	// oldest first, tells the clients and posts the bookings to the admin
+	// chat. Failed notifications are returned joined, the promotions stay.
+	Promote(ctx context.Context, hikeID int32) error
+}
+
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type service struct {
+	db          txBeginner
+	queries     *client.Queries
+	clientBot   *tgbot.BotAPI
+	adminChatID int64
+	notify      notify.Service
+	loc         *time.Location
+}
+
+func New(db txBeginner, q *client.Queries, clientBot *tgbot.BotAPI, adminChatID int64, n notify.Service, loc *time.Location) Service {
+	return &service{
+		db:          db,
+		queries:     q,
+		clientBot:   clientBot,
+		adminChatID: adminChatID,
+		notify:      n,
+		loc:         loc,
+	}
+}
+
+func (s *service) Promote(ctx context.Context, hikeID int32) error {
+	var errs []error
+
+	for {
+		promotedID, err := s.promoteNext(ctx, hikeID)
+		if err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if promotedID == 0 {
+			break
+		}
+
+		if err := s.announce(ctx, promotedID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// promoteNext moves the oldest waitlisted booking of the hike to new if the
+// hike has a free seat. It returns 0 when nobody was promoted.
+func (s *service) promoteNext(ctx context.Context, hikeID int32) (int32, error) {
+	var promotedID int32
+
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		q := s.queries.WithTx(tx)
+
+		// Nobody is promoted into a hidden or closed hike
+		maxParticipants, err := q.GetHikeCapacityForUpdate(ctx, hikeID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if maxParticipants.Valid {
+			active, err := q.CountActiveBookings(ctx, hikeID)
+			if err != nil {
+				return err
+			}
+			if active >= int64(maxParticipants.Int32) {
+				return nil
+			}
+		}
+
+		promotedID, err = q.PromoteNextWaitlistedBooking(ctx, hikeID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return 0, logger.WrapError(err)
+	}
+
+	return promotedID, nil
+}
+
+// announce tells the client about the seat and posts the booking to the
+// admin chat, even if the client couldn't be reached.
+func (s *service) announce(ctx context.Context, bookingID int32) error {
+	b, err := s.queries.GetBookingDetails(ctx, bookingID)
+	if err != nil {
+		return logger.WrapError(err)
+	}
+
+	var errs []error
+
+	err = s.notify.Send(ctx, b.UserTgID, b.UserLang, notify.WaitlistPromoted, notify.Booking{
+		ID:           b.ID,
+		HikeTitle:    i18n.Localized(b.UserLang, b.HikeTitle, b.HikeTitleEn),
+		HikeStartsAt: b.HikeStartsAt,
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	msg := tgbot.NewMessage(s.adminChatID, adminMessage(b, s.loc))
+	msg.ParseMode = tgbot.ModeHTML
+	msg.ReplyMarkup = takeKeyboard(b.ID)
+
+	sent, err := s.clientBot.Send(msg)
+	if err != nil {
+		errs = append(errs, logger.WrapError(fmt.Errorf("failed to send admin message to chat=%v: %w", s.adminChatID, err)))
+		return errors.Join(errs...)
+	}
+
+	err = s.queries.SetBookingAdminMessage(ctx, client.SetBookingAdminMessageParams{
+		ID:             b.ID,
+		AdminMessageID: pgtype.Int8{Int64: int64(sent.MessageID), Valid: true},
+	})
+	if err != nil {
+		errs = append(errs, logger.WrapError(err))
+	}
+
+	return errors.Join(errs...)
+}
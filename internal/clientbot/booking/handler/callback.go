@@ -7,12 +7,12 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
 	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
-	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
 
 	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/booking"
 )
@@ -22,10 +22,15 @@ func (h *Handler) BookHike(ctx context.Context, q *tgbot.CallbackQuery) error {
 		return nil
 	}
 
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
 	idStr := strings.TrimPrefix(q.Data, "book_hike:")
 	hikeID64, err := strconv.ParseInt(idStr, 10, 32)
 	if err != nil {
-		h.replyCallback(q, "Не удалось обработать запрос.")
+		h.replyCallback(q, i18n.T(lang, i18n.ClientBookingBadRequest))
 		return logger.WrapError(fmt.Errorf("parse hike id error: %v (data=%q)", err, q.Data))
 	}
 	hikeID := int32(hikeID64)
@@ -35,13 +40,9 @@ func (h *Handler) BookHike(ctx context.Context, q *tgbot.CallbackQuery) error {
 	fullName := strings.TrimSpace(q.From.FirstName + " " + q.From.LastName)
 
 	// 1) Check if user exists
-	userID, err := h.userService.EnsureTelegramUser(ctx, userService.TelegramUser{
-		TgUserID:   tgUserID,
-		TgUsername: username,
-		FullName:   fullName,
-	})
+	userID, err := h.userService.EnsureFromTelegram(ctx, q.From)
 	if err != nil {
-		_ = h.replyCallback(q, "Ошибка. Пожалуйста, попробуйте позже.")
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientError))
 		return err
 	}
 
@@ -49,66 +50,342 @@ func (h *Handler) BookHike(ctx context.Context, q *tgbot.CallbackQuery) error {
 	hike, err := h.hikeService.GetHike(ctx, hikeID)
 	if err != nil {
 		if errors.Is(err, hikeService.ErrHikesNotFound) {
-			_ = h.replyCallback(q, "К сожалению, этот хайк недоступен.")
+			_ = h.replyCallback(q, i18n.T(lang, i18n.ClientBookingHikeUnavailable))
 		}
 		return err
 	}
 
-	// 3) Create booking and set status to new
-	bookingID, err := h.bookingService.Create(ctx, hikeID, userID)
+	// 3) Create booking: new, or waitlisted if the hike is full
+	booking, err := h.bookingService.Create(ctx, hikeID, userID)
 	if err != nil {
 		if errors.Is(err, bookingService.ErrBookingAlreadyExists) {
-			_ = h.replyCallback(q, "У Вас уже есть заявка на этот хайк ✅ Мы её обрабатываем.")
+			_ = h.replyCallback(q, bookingUI.AlreadyBookedMessage(booking.Status, lang))
+			return err
+		}
+		if errors.Is(err, hikeService.ErrHikesNotFound) {
+			_ = h.replyCallback(q, i18n.T(lang, i18n.ClientBookingHikeUnavailable))
 			return err
 		}
-		_ = h.replyCallback(q, "Ошибка. Пожалуйста, попробуйте позже.")
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientError))
 		return logger.WrapError(fmt.Errorf("failed to create booking: %w", err))
 	}
 
 	// 4) Change inline-button text
-	newKb := tgbot.NewInlineKeyboardMarkup(
-		tgbot.NewInlineKeyboardRow(
-			tgbot.NewInlineKeyboardButtonData(
-				"⏳ Запрос отправлен",
-				"booking_sent",
-			),
-		),
-	)
+	buttonText := i18n.T(lang, i18n.BtnClientBookingSent)
+	if booking.Status == bookingService.StatusWaitlisted {
+		buttonText = i18n.T(lang, i18n.BtnClientBookingWaitlisted)
+	}
 
 	// 5) Send new Message with changed button
 	edit := tgbot.NewEditMessageReplyMarkup(
 		q.Message.Chat.ID,
 		q.Message.MessageID,
-		newKb,
+		bookingUI.BookedKeyboard(q.Message.ReplyMarkup, buttonText),
 	)
 	if _, err := h.bot.Send(edit); err != nil {
 		return logger.WrapError(err)
 	}
 
-	// 6) Info user if hike is booked successfully
-	_ = h.replyCallback(q, "Ваша заявка отправлена ✅ Мы передали её менеджерам.")
+	// 6) Info user if hike is booked successfully.
+	// Waitlisted bookings reach admins only once a seat frees up.
+	if booking.Status == bookingService.StatusWaitlisted {
+		return h.replyCallback(q, bookingUI.WaitlistedMessage(booking.WaitlistPosition, lang))
+	}
+
+	if booking.Rebooked {
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientBookingRebooked))
+	} else {
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientBookingSent))
+	}
 
 	// 7) Form and send admin message
 	msg := tgbot.NewMessage(h.cfg.AdminChatID, bookingUI.AdminBookingMessage(
 		hike,
-		bookingID,
+		booking.ID,
 		tgUserID,
 		username,
 		fullName,
 		h.cfg.AdminBotName,
 	))
 	msg.ParseMode = "HTML"
-	msg.ReplyMarkup = bookingUI.AdminBookingKeyboard(bookingID)
+	msg.ReplyMarkup = bookingUI.AdminBookingKeyboard(booking.ID)
 
-	if _, err := h.bot.Send(msg); err != nil {
+	sent, err := h.bot.Send(msg)
+	if err != nil {
 		return logger.WrapError(fmt.Errorf("failed to send admin message to chat=%v: %w", h.cfg.AdminChatID, err))
 	}
 
-	return nil
+	// 8) Remember the admin message to mark it when the client cancels
+	return h.bookingService.SetAdminMessageID(ctx, booking.ID, sent.MessageID)
 }
 
 func (h *Handler) BookSent(ctx context.Context, q *tgbot.CallbackQuery) error {
-	return h.replyCallback(q, "Заявка уже отправлена ✅")
+	if q == nil || q.From == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	return h.replyCallback(q, i18n.T(lang, i18n.ClientBookingAlreadySent))
+}
+
+func (h *Handler) AskCancelBooking(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	bookingID, err := parseBookingID(q.Data, "my_booking_cancel:")
+	if err != nil {
+		return err
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbot.NewEditMessageReplyMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		bookingUI.CancelReasonKeyboard(bookingID, lang),
+	)
+	if _, err := h.bot.Send(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, i18n.T(lang, i18n.ClientCancelAskReason))
+}
+
+func (h *Handler) SelectCancelReason(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	// my_booking_cancel_reason:15:plans
+	parts := strings.Split(strings.TrimPrefix(q.Data, "my_booking_cancel_reason:"), ":")
+	if len(parts) != 2 {
+		return logger.WrapError(fmt.Errorf("bad cancel reason data: %q", q.Data))
+	}
+
+	bookingID, err := parseBookingID(parts[0], "")
+	if err != nil {
+		return err
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	if parts[1] == "other" {
+		h.setAwaitingCancelReason(q.From.ID, bookingID)
+
+		msg := tgbot.NewMessage(q.Message.Chat.ID, i18n.T(lang, i18n.ClientCancelTypeReason))
+		msg.ReplyMarkup = bookingUI.CustomCancelReasonKeyboard(lang)
+		if _, err := h.bot.Send(msg); err != nil {
+			return logger.WrapError(err)
+		}
+
+		return h.replyCallback(q, "")
+	}
+
+	reason, ok := bookingUI.CancelReasonText(parts[1])
+	if !ok {
+		return logger.WrapError(fmt.Errorf("unknown cancel reason: %q", q.Data))
+	}
+
+	text, err := h.cancelBooking(ctx, q.From, bookingID, reason, lang)
+	_ = h.replyCallback(q, text)
+
+	if refreshErr := h.refreshMyBookings(ctx, q, lang); refreshErr != nil && err == nil {
+		err = refreshErr
+	}
+
+	return err
+}
+
+func (h *Handler) AbortCancelReason(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	h.takeAwaitingCancelReason(q.From.ID)
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbot.NewEditMessageText(q.Message.Chat.ID, q.Message.MessageID, i18n.T(lang, i18n.ClientCancelKept))
+	if _, err := h.bot.Request(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) AskPaymentReceipt(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	bookingID, err := parseBookingID(q.Data, "my_booking_pay:")
+	if err != nil {
+		return err
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	h.setAwaitingReceipt(q.From.ID, bookingID)
+
+	msg := tgbot.NewMessage(q.Message.Chat.ID, bookingUI.PaymentReceiptPrompt(lang))
+	msg.ReplyMarkup = bookingUI.PaymentReceiptKeyboard(lang)
+	if _, err := h.bot.Send(msg); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) AbortPaymentReceipt(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	h.takeAwaitingReceipt(q.From.ID)
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbot.NewEditMessageText(q.Message.Chat.ID, q.Message.MessageID, i18n.T(lang, i18n.ClientReceiptLater))
+	if _, err := h.bot.Request(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+// KeepBookingAfterChange answers the hike change notification: the client
+// accepts the new details and keeps the booking.
+func (h *Handler) KeepBookingAfterChange(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.removeKeyboard(q); err != nil {
+		return err
+	}
+
+	return h.replyCallback(q, i18n.T(lang, i18n.ClientHikeChangeKept))
+}
+
+// CancelBookingAfterChange answers the hike change notification: the new
+// details don't suit the client, so the booking is canceled in one tap.
+func (h *Handler) CancelBookingAfterChange(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	bookingID, err := parseBookingID(q.Data, "hike_change_cancel:")
+	if err != nil {
+		return err
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	text, err := h.cancelBooking(ctx, q.From, bookingID, "Не подошли изменения в хайке", lang)
+	_ = h.replyCallback(q, text)
+
+	if keyboardErr := h.removeKeyboard(q); keyboardErr != nil && err == nil {
+		err = keyboardErr
+	}
+
+	return err
+}
+
+// removeKeyboard drops the inline buttons from the message the callback came from.
+func (h *Handler) removeKeyboard(q *tgbot.CallbackQuery) error {
+	edit := tgbot.NewEditMessageReplyMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		tgbot.InlineKeyboardMarkup{InlineKeyboard: [][]tgbot.InlineKeyboardButton{}},
+	)
+
+	_, err := h.bot.Request(edit)
+	return logger.WrapError(err)
+}
+
+// cancelBooking cancels the booking on behalf of the telegram user and
+// returns the text to show them.
+func (h *Handler) cancelBooking(ctx context.Context, from *tgbot.User, bookingID int32, reason, lang string) (string, error) {
+	userID, err := h.userService.EnsureFromTelegram(ctx, from)
+	if err != nil {
+		return i18n.T(lang, i18n.ClientError), err
+	}
+
+	err = h.bookingService.Cancel(ctx, bookingID, userID, reason)
+	switch {
+	case err == nil:
+		return i18n.T(lang, i18n.ClientCancelDone), nil
+
+	// The booking is canceled anyway, only the follow-ups failed
+	case errors.Is(err, bookingService.ErrWaitlistPromotion),
+		errors.Is(err, bookingService.ErrCancelNotification):
+		return i18n.T(lang, i18n.ClientCancelDone), err
+
+	case errors.Is(err, bookingService.ErrBookingNotCancelable):
+		return i18n.T(lang, i18n.ClientCancelNotAllowed), err
+
+	default:
+		return i18n.T(lang, i18n.ClientError), err
+	}
+}
+
+func (h *Handler) ShowMyBookings(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := h.refreshMyBookings(ctx, q, lang); err != nil {
+		return err
+	}
+
+	return h.replyCallback(q, "")
+}
+
+// refreshMyBookings re-renders the "Мои записи" message the callback came from.
+func (h *Handler) refreshMyBookings(ctx context.Context, q *tgbot.CallbackQuery, lang string) error {
+	text, kb, err := h.myBookings(ctx, q.From, lang)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbot.NewEditMessageTextAndMarkup(q.Message.Chat.ID, q.Message.MessageID, text, kb)
+	edit.ParseMode = tgbot.ModeHTML
+
+	_, err = h.bot.Request(edit)
+	return logger.WrapError(err)
 }
 
 func (h *Handler) TakeBooking(ctx context.Context, q *tgbot.CallbackQuery) error {
@@ -117,15 +394,10 @@ func (h *Handler) TakeBooking(ctx context.Context, q *tgbot.CallbackQuery) error
 	}
 
 	// Ensure telegram user (admin) exists
-	tgUserID := q.From.ID
 	tgUserName := q.From.UserName
 	tgFullName := strings.TrimSpace(q.From.FirstName + " " + q.From.LastName)
 
-	userID, err := h.userService.EnsureTelegramUser(ctx, userService.TelegramUser{
-		TgUserID:   tgUserID,
-		TgUsername: tgUserName,
-		FullName:   tgFullName,
-	})
+	userID, err := h.userService.EnsureFromTelegram(ctx, q.From)
 	if err != nil {
 		return err
 	}
@@ -162,7 +434,7 @@ func (h *Handler) TakeBooking(ctx context.Context, q *tgbot.CallbackQuery) error
 	}
 
 	// Notify client
-	err = h.notifyClientBookingTaken(ctx, bookingID)
+	err = h.bookingService.NotifyTaken(ctx, bookingID)
 	if err != nil {
 		return fmt.Errorf("failed to notify client about taken booking: %w", err)
 	}
@@ -170,26 +442,6 @@ func (h *Handler) TakeBooking(ctx context.Context, q *tgbot.CallbackQuery) error
 	return nil
 }
 
-func (h *Handler) notifyClientBookingTaken(ctx context.Context, bookingID int32) error {
-	booking, err := h.bookingService.GetByID(ctx, bookingID)
-	if err != nil {
-		return err
-	}
-
-	user, err := h.userService.GetByID(ctx, booking.UserID)
-	if err != nil {
-		return err
-	}
-
-	msg := tgbot.NewMessage(
-		user.TgUserID,
-		bookingUI.ClientBookingMessage(),
-	)
-
-	_, err = h.bot.Send(msg)
-	return err
-}
-
 func (h *Handler) updateBookingTakenMessage(q *tgbot.CallbackQuery, fullName, username string) error {
 	text := q.Message.Text
 	if text == "" {
@@ -209,6 +461,14 @@ func (h *Handler) updateBookingTakenMessage(q *tgbot.CallbackQuery, fullName, us
 	return logger.WrapError(err)
 }
 
+func parseBookingID(data, prefix string) (int32, error) {
+	id64, err := strconv.ParseInt(strings.TrimPrefix(data, prefix), 10, 32)
+	if err != nil {
+		return 0, logger.WrapError(fmt.Errorf("parse booking id error: %v (data=%q)", err, data))
+	}
+	return int32(id64), nil
+}
+
 func (h *Handler) replyCallback(q *tgbot.CallbackQuery, text string) error {
 	cfg := tgbot.CallbackConfig{
 		CallbackQueryID: q.ID,
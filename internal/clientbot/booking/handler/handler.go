@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"sync"
+
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/config"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
@@ -17,6 +19,12 @@ type Handler struct {
 	adminService   adminService.Service
 	hikeService    hikeService.Service
 	bookingService bookingService.Service
+
+	// cancelReasons and receipts hold the booking a client is typing a cancel
+	// reason or sending a payment receipt for, by tg user ID
+	mu            sync.Mutex
+	cancelReasons map[int64]int32
+	receipts      map[int64]int32
 }
 
 func New(
@@ -34,5 +42,7 @@ func New(
 		adminService:   aS,
 		hikeService:    hS,
 		bookingService: bS,
+		cancelReasons:  make(map[int64]int32),
+		receipts:       make(map[int64]int32),
 	}
 }
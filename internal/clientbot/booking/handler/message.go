@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
+
+	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/booking"
+)
+
+func (h *Handler) ListMyBookings(ctx context.Context, m *tgbot.Message) error {
+	if m == nil || m.From == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	text, kb, err := h.myBookings(ctx, m.From, lang)
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientMyBookingsLoadFailed)))
+		return err
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, text)
+	msg.ParseMode = tgbot.ModeHTML
+	msg.ReplyMarkup = kb
+
+	_, err = h.bot.Send(msg)
+	return logger.WrapError(err)
+}
+
+// ListCancelableBookings answers the /cancel command.
+func (h *Handler) ListCancelableBookings(ctx context.Context, m *tgbot.Message) error {
+	if m == nil || m.From == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	userID, err := h.userService.EnsureFromTelegram(ctx, m.From)
+	if err != nil {
+		return err
+	}
+
+	bookings, err := h.bookingService.ListUserBookings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	upcoming, _ := bookingUI.SplitByDate(bookings, time.Now())
+	kb := bookingUI.CancelableBookingsKeyboard(upcoming, lang)
+
+	if len(kb.InlineKeyboard) == 0 {
+		_, err = h.bot.Send(tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientCancelNone)))
+		return logger.WrapError(err)
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientCancelChoose))
+	msg.ReplyMarkup = kb
+
+	_, err = h.bot.Send(msg)
+	return logger.WrapError(err)
+}
+
+func (h *Handler) AwaitingCancelReason(tgUserID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, ok := h.cancelReasons[tgUserID]
+	return ok
+}
+
+// HandleCancelReason takes the custom reason typed after "✍️ Другая причина".
+func (h *Handler) HandleCancelReason(ctx context.Context, m *tgbot.Message) error {
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	reason := strings.TrimSpace(m.Text)
+	if reason == "" {
+		msg := tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientCancelReasonAsText))
+		msg.ReplyMarkup = bookingUI.CustomCancelReasonKeyboard(lang)
+
+		_, err := h.bot.Send(msg)
+		return logger.WrapError(err)
+	}
+
+	bookingID, ok := h.takeAwaitingCancelReason(m.From.ID)
+	if !ok {
+		return nil
+	}
+
+	text, err := h.cancelBooking(ctx, m.From, bookingID, reason, lang)
+	if _, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, text)); sendErr != nil && err == nil {
+		err = logger.WrapError(sendErr)
+	}
+
+	return err
+}
+
+func (h *Handler) setAwaitingCancelReason(tgUserID int64, bookingID int32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.cancelReasons[tgUserID] = bookingID
+}
+
+func (h *Handler) takeAwaitingCancelReason(tgUserID int64) (int32, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bookingID, ok := h.cancelReasons[tgUserID]
+	delete(h.cancelReasons, tgUserID)
+	return bookingID, ok
+}
+
+// DropAwaiting forgets the cancel reason or receipt the client was asked for.
+func (h *Handler) DropAwaiting(tgUserID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.cancelReasons, tgUserID)
+	delete(h.receipts, tgUserID)
+}
+
+func (h *Handler) AwaitingReceipt(tgUserID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, ok := h.receipts[tgUserID]
+	return ok
+}
+
+// HandleReceipt takes the receipt photo sent after "💳 Отправить чек об оплате".
+// The amount is read from the caption, without one the full price is assumed.
+func (h *Handler) HandleReceipt(ctx context.Context, m *tgbot.Message) error {
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(m.Photo) == 0 {
+		msg := tgbot.NewMessage(m.Chat.ID, bookingUI.PaymentReceiptPrompt(lang))
+		msg.ReplyMarkup = bookingUI.PaymentReceiptKeyboard(lang)
+
+		_, err := h.bot.Send(msg)
+		return logger.WrapError(err)
+	}
+
+	amount, ok := parseAmount(m.Caption)
+	if !ok {
+		msg := tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientReceiptBadAmount))
+		msg.ReplyMarkup = bookingUI.PaymentReceiptKeyboard(lang)
+
+		_, err := h.bot.Send(msg)
+		return logger.WrapError(err)
+	}
+
+	bookingID, ok := h.takeAwaitingReceipt(m.From.ID)
+	if !ok {
+		return nil
+	}
+
+	userID, err := h.userService.EnsureFromTelegram(ctx, m.From)
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientError)))
+		return err
+	}
+
+	// The largest size comes last
+	fileID := m.Photo[len(m.Photo)-1].FileID
+
+	payment, err := h.bookingService.SubmitPayment(ctx, bookingID, userID, amount, fileID)
+
+	var text string
+	switch {
+	case err == nil:
+		text = bookingUI.PaymentSubmittedMessage(payment, lang)
+
+	// The receipt is saved, only the manager wasn't reached
+	case errors.Is(err, bookingService.ErrPaymentNotification):
+		text = i18n.T(lang, i18n.ClientReceiptSaved)
+
+	case errors.Is(err, bookingService.ErrPaymentNotAllowed):
+		text = i18n.T(lang, i18n.ClientReceiptNotAllowed)
+
+	default:
+		text = i18n.T(lang, i18n.ClientReceiptFailed)
+	}
+
+	if _, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, text)); sendErr != nil && err == nil {
+		err = logger.WrapError(sendErr)
+	}
+
+	return err
+}
+
+func (h *Handler) setAwaitingReceipt(tgUserID int64, bookingID int32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.receipts[tgUserID] = bookingID
+}
+
+func (h *Handler) takeAwaitingReceipt(tgUserID int64) (int32, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bookingID, ok := h.receipts[tgUserID]
+	delete(h.receipts, tgUserID)
+	return bookingID, ok
+}
+
+var amountRe = regexp.MustCompile(`\d+(?:[.,]\d{1,2})?`)
+
+// parseAmount finds the paid amount in the receipt caption, 0 if there is no caption.
+func parseAmount(caption string) (float64, bool) {
+	caption = strings.TrimSpace(caption)
+	if caption == "" {
+		return 0, true
+	}
+
+	match := amountRe.FindString(caption)
+	if match == "" {
+		return 0, false
+	}
+
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(match, ",", "."), 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+
+	return amount, true
+}
+
+// myBookings renders the "Мои записи" message for the telegram user.
+func (h *Handler) myBookings(ctx context.Context, from *tgbot.User, lang string) (string, tgbot.InlineKeyboardMarkup, error) {
+	userID, err := h.userService.EnsureFromTelegram(ctx, from)
+	if err != nil {
+		return "", tgbot.InlineKeyboardMarkup{}, err
+	}
+
+	bookings, err := h.bookingService.ListUserBookings(ctx, userID)
+	if err != nil {
+		return "", tgbot.InlineKeyboardMarkup{}, err
+	}
+
+	upcoming, past := bookingUI.SplitByDate(bookings, time.Now())
+
+	return bookingUI.MyBookingsMessage(upcoming, past, lang), bookingUI.MyBookingsKeyboard(upcoming, lang), nil
+}
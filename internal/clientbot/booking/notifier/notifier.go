@@ -0,0 +1,150 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/config"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/notify"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
+	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
+	bookingUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/booking"
+)
+
+// notifier posts to clients and the admin chat through the client bot.
+// Managers are messaged privately through the admin bot, since that is
+// the bot they have started.
+type notifier struct {
+	bot      *tgbot.BotAPI
+	adminBot *tgbot.BotAPI
+	cfg      config.ClientBot
+	notify   notify.Service
+}
+
+func New(b, adminBot *tgbot.BotAPI, c config.ClientBot, n notify.Service) service.Notifier {
+	return &notifier{
+		bot:      b,
+		adminBot: adminBot,
+		cfg:      c,
+		notify:   n,
+	}
+}
+
+// NotifyCanceledByClient marks the booking message in the admin chat as canceled
+// (which also removes its buttons) and tells the assigned manager, if any.
+func (n *notifier) NotifyCanceledByClient(ctx context.Context, booking service.Booking) error {
+	if booking.AdminMessageID != 0 {
+		text := n.adminBookingMessage(booking)
+		if booking.TakenByAdminID != nil {
+			text = bookingUI.BookingTakenMessage(text, booking.AdminName, booking.AdminUsername)
+		}
+		text = bookingUI.BookingCanceledByClientMessage(text, booking.CancelReason)
+
+		edit := tgbot.NewEditMessageText(n.cfg.AdminChatID, booking.AdminMessageID, text)
+		edit.ParseMode = tgbot.ModeHTML
+
+		if _, err := n.bot.Request(edit); err != nil {
+			return logger.WrapError(fmt.Errorf("failed to edit admin message id=%d: %w", booking.AdminMessageID, err))
+		}
+	}
+
+	if booking.AdminTgID != 0 {
+		msg := tgbot.NewMessage(booking.AdminTgID, bookingUI.ManagerBookingCanceledMessage(booking))
+		msg.ParseMode = tgbot.ModeHTML
+
+		if _, err := n.adminBot.Send(msg); err != nil {
+			return logger.WrapError(fmt.Errorf("failed to notify manager tg_id=%d: %w", booking.AdminTgID, err))
+		}
+	}
+
+	return nil
+}
+
+func (n *notifier) NotifyTaken(ctx context.Context, booking service.Booking) error {
+	return n.notify.Send(ctx, booking.UserTgID, booking.UserLang, notify.BookingTaken, notify.Booking{
+		ID:              booking.ID,
+		HikeTitle:       booking.HikeTitleIn(booking.UserLang),
+		HikeStartsAt:    booking.HikeStartsAt,
+		ManagerName:     booking.AdminName,
+		ManagerUsername: booking.AdminUsername,
+	})
+}
+
+// NotifyPaymentSubmitted re-uploads the receipt through the admin bot:
+// file IDs are bound to the bot that received the file.
+func (n *notifier) NotifyPaymentSubmitted(ctx context.Context, booking service.Booking, payment service.Payment) error {
+	if booking.AdminTgID == 0 {
+		return logger.WrapError(fmt.Errorf("booking id=%d has no manager to review the payment", booking.ID))
+	}
+
+	receipt, err := n.downloadFile(ctx, payment.ProofFileID)
+	if err != nil {
+		return err
+	}
+
+	photo := tgbot.NewPhoto(booking.AdminTgID, tgbot.FileBytes{
+		Name:  fmt.Sprintf("receipt_%d.jpg", payment.ID),
+		Bytes: receipt,
+	})
+	photo.Caption = bookingUI.ManagerPaymentSubmittedMessage(booking, payment)
+	photo.ParseMode = tgbot.ModeHTML
+	photo.ReplyMarkup = bookingUI.PaymentReviewKeyboard(payment.ID)
+
+	if _, err := n.adminBot.Send(photo); err != nil {
+		return logger.WrapError(fmt.Errorf("failed to send receipt to manager tg_id=%d: %w", booking.AdminTgID, err))
+	}
+
+	return nil
+}
+
+func (n *notifier) downloadFile(ctx context.Context, fileID string) ([]byte, error) {
+	url, err := n.bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, logger.WrapError(fmt.Errorf("failed to download file id=%s: %s", fileID, resp.Status))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	return data, nil
+}
+
+func (n *notifier) adminBookingMessage(booking service.Booking) string {
+	hike := hikeService.Hike{
+		ID:       booking.HikeID,
+		TitleRu:  booking.HikeTitle,
+		StartsAt: booking.HikeStartsAt,
+		EndsAt:   booking.HikeEndsAt,
+	}
+
+	return bookingUI.AdminBookingMessage(
+		hike,
+		booking.ID,
+		booking.UserTgID,
+		booking.UserUsername,
+		booking.UserName,
+		n.cfg.AdminBotName,
+	)
+}
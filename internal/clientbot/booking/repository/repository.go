@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"errors"
+	"math"
+	"math/big"
 	"strings"
 	"time"
 
@@ -12,14 +14,20 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
+	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
 )
 
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type repository struct {
+	db      txBeginner
 	queries *client.Queries
 }
 
-func New(q *client.Queries) service.Repository {
-	return &repository{queries: q}
+func New(db txBeginner, q *client.Queries) service.Repository {
+	return &repository{db: db, queries: q}
 }
 
 func (r *repository) GetByID(ctx context.Context, id int32) (service.Booking, error) {
@@ -48,20 +56,87 @@ func (r *repository) GetByID(ctx context.Context, id int32) (service.Booking, er
 	}, nil
 }
 
-func (r *repository) Create(ctx context.Context, booking service.Booking) (int32, error) {
-	id, err := r.queries.CreateBooking(ctx, client.CreateBookingParams{
-		HikeID: booking.HikeID,
-		UserID: booking.UserID,
-		Status: string(service.StatusNew),
+// Create inserts the booking while holding a lock on the hike row, so
+// concurrent bookings can't overfill it. Overflow goes to the waitlist.
+// A hike hidden or closed for booking meanwhile gives ErrHikesNotFound.
+// Canceled and completed bookings of the client don't prevent a new one.
+func (r *repository) Create(ctx context.Context, booking service.Booking) (service.Booking, error) {
+	var existing service.Booking
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		q := r.queries.WithTx(tx)
+
+		maxParticipants, err := q.GetHikeCapacityForUpdate(ctx, booking.HikeID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return hikeService.ErrHikesNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		latest, err := q.GetLatestUserHikeBooking(ctx, client.GetLatestUserHikeBookingParams{
+			HikeID: booking.HikeID,
+			UserID: booking.UserID,
+		})
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+		case err != nil:
+			return err
+		case service.BookingStatus(latest.Status).IsActive():
+			existing = service.Booking{
+				ID:     latest.ID,
+				HikeID: booking.HikeID,
+				UserID: booking.UserID,
+				Status: service.BookingStatus(latest.Status),
+			}
+			return service.ErrBookingAlreadyExists
+		default:
+			booking.Rebooked = true
+		}
+
+		booking.Status = service.StatusNew
+		if maxParticipants.Valid {
+			active, err := q.CountActiveBookings(ctx, booking.HikeID)
+			if err != nil {
+				return err
+			}
+			if active >= int64(maxParticipants.Int32) {
+				booking.Status = service.StatusWaitlisted
+			}
+		}
+
+		booking.ID, err = q.CreateBooking(ctx, client.CreateBookingParams{
+			HikeID: booking.HikeID,
+			UserID: booking.UserID,
+			Status: string(booking.Status),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return service.ErrBookingAlreadyExists
+			}
+			return err
+		}
+
+		if booking.Status == service.StatusWaitlisted {
+			booking.WaitlistPosition, err = q.GetWaitlistPosition(ctx, client.GetWaitlistPositionParams{
+				HikeID: booking.HikeID,
+				ID:     booking.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
 	})
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, logger.WrapError(service.ErrBookingAlreadyExists)
+		if errors.Is(err, service.ErrBookingAlreadyExists) {
+			return existing, logger.WrapError(err)
 		}
-		return 0, logger.WrapError(err)
+		return service.Booking{}, logger.WrapError(err)
 	}
 
-	return id, nil
+	return booking, nil
 }
 
 func (r *repository) TakeInProgress(ctx context.Context, bookingID, adminID int32) (int32, error) {
@@ -81,6 +156,107 @@ func (r *repository) TakeInProgress(ctx context.Context, bookingID, adminID int3
 	return inProgressBookingID, nil
 }
 
+func (r *repository) ListByUser(ctx context.Context, userID int32) ([]service.Booking, error) {
+	rows, err := r.queries.ListUserBookings(ctx, userID)
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	bookings := make([]service.Booking, 0, len(rows))
+	for _, row := range rows {
+		bookings = append(bookings, service.Booking{
+			ID:           row.ID,
+			HikeID:       row.HikeID,
+			HikeTitle:    row.HikeTitle,
+			HikeTitleEn:  row.HikeTitleEn,
+			HikeStartsAt: row.HikeStartsAt,
+			HikeEndsAt:   row.HikeEndsAt,
+			UserID:       userID,
+			Status:       service.BookingStatus(row.Status),
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+
+	return bookings, nil
+}
+
+func (r *repository) Cancel(ctx context.Context, bookingID, userID int32, reason string) (int32, error) {
+	hikeID, err := r.queries.CancelBookingByClient(ctx, client.CancelBookingByClientParams{
+		ID:           bookingID,
+		UserID:       userID,
+		CancelReason: toPgText(reason),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, logger.WrapError(service.ErrBookingNotCancelable)
+		}
+		return 0, logger.WrapError(err)
+	}
+
+	return hikeID, nil
+}
+
+func (r *repository) SetAdminMessageID(ctx context.Context, bookingID int32, messageID int) error {
+	err := r.queries.SetBookingAdminMessage(ctx, client.SetBookingAdminMessageParams{
+		ID:             bookingID,
+		AdminMessageID: pgtype.Int8{Int64: int64(messageID), Valid: true},
+	})
+	return logger.WrapError(err)
+}
+
+func (r *repository) CreatePayment(ctx context.Context, payment service.Payment) (int32, error) {
+	id, err := r.queries.CreatePayment(ctx, client.CreatePaymentParams{
+		BookingID:   payment.BookingID,
+		Amount:      toPgNumeric(payment.Amount),
+		Currency:    payment.Currency,
+		ProofFileID: toPgText(payment.ProofFileID),
+	})
+	if err != nil {
+		return 0, logger.WrapError(err)
+	}
+
+	return id, nil
+}
+
+func (r *repository) GetDetails(ctx context.Context, id int32) (service.Booking, error) {
+	row, err := r.queries.GetBookingDetails(ctx, id)
+	if err != nil {
+		return service.Booking{}, logger.WrapError(err)
+	}
+
+	return toServiceBooking(row), nil
+}
+
+func toServiceBooking(row client.GetBookingDetailsRow) service.Booking {
+	var takenByAdminID *int32
+	if row.TakenByAdminID.Valid {
+		takenByAdminID = &row.TakenByAdminID.Int32
+	}
+
+	return service.Booking{
+		ID:             row.ID,
+		HikeID:         row.HikeID,
+		HikeTitle:      row.HikeTitle,
+		HikeTitleEn:    row.HikeTitleEn,
+		HikeStartsAt:   row.HikeStartsAt,
+		HikeEndsAt:     row.HikeEndsAt,
+		HikePriceGel:   row.HikePriceGel,
+		UserID:         row.UserID,
+		UserName:       row.UserName,
+		UserUsername:   row.UserUsername,
+		UserTgID:       row.UserTgID,
+		UserLang:       row.UserLang,
+		Status:         service.BookingStatus(row.Status),
+		TakenByAdminID: takenByAdminID,
+		AdminName:      row.AdminName,
+		AdminUsername:  row.AdminUsername,
+		AdminTgID:      row.AdminTgID.Int64,
+		AdminMessageID: int(row.AdminMessageID.Int64),
+		CancelReason:   row.CancelReason.String,
+		CreatedAt:      row.CreatedAt,
+	}
+}
+
 // TODO: вынести отдельно
 func toPgText(s string) pgtype.Text {
 	s = strings.TrimSpace(s)
@@ -90,6 +266,11 @@ func toPgText(s string) pgtype.Text {
 	return pgtype.Text{String: s, Valid: true}
 }
 
+// toPgNumeric keeps two decimal places, as in payments.amount.
+func toPgNumeric(f float64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(int64(math.Round(f * 100))), Exp: -2, Valid: true}
+}
+
 func toPgInt4(i int32) pgtype.Int4 {
 	return pgtype.Int4{Int32: i, Valid: true}
 }
@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 )
 
 type BookingStatus string
@@ -14,59 +17,128 @@ const (
 	StatusConfirmed  BookingStatus = "confirmed"
 	StatusCompleted  BookingStatus = "completed"
 	StatusCanceled   BookingStatus = "canceled"
+	StatusWaitlisted BookingStatus = "waitlisted"
 )
 
-var bookingStatuses = map[BookingStatus]string{
-	StatusNew:        "новая",
-	StatusInProgress: "в работе",
-	StatusConfirmed:  "подтверждена",
-	StatusCompleted:  "завершена",
-	StatusCanceled:   "отменена",
-}
-
-func (b BookingStatus) String() string {
-	return bookingStatuses[b]
+// IsActive reports whether the booking still holds or waits for a seat,
+// i.e. the client can cancel it.
+func (b BookingStatus) IsActive() bool {
+	switch b {
+	case StatusNew, StatusWaitlisted, StatusInProgress, StatusConfirmed:
+		return true
+	default:
+		return false
+	}
 }
 
 var (
 	ErrBookingAlreadyExists = errors.New("booking already exists")
 	ErrBookingAlreadyTaken  = errors.New("booking already taken")
+	ErrBookingNotCancelable = errors.New("booking can't be canceled")
+	ErrWaitlistPromotion    = errors.New("waitlist promotion failed")
+	ErrCancelNotification   = errors.New("cancel notification failed")
+	ErrPaymentNotAllowed    = errors.New("payment not allowed for booking")
+	ErrPaymentNotification  = errors.New("payment notification failed")
 )
 
+// PaymentCurrency is the only currency hikes are priced in.
+const PaymentCurrency = "GEL"
+
+type Payment struct {
+	ID          int32
+	BookingID   int32
+	Amount      float64
+	Currency    string
+	ProofFileID string
+}
+
 type Booking struct {
-	ID             int32
-	HikeID         int32
-	UserID         int32
-	Status         BookingStatus
-	TakenByAdminID *int32
-	TakenAt        *time.Time
+	ID               int32
+	HikeID           int32
+	HikeTitle        string
+	HikeTitleEn      string
+	HikeStartsAt     time.Time
+	HikeEndsAt       time.Time
+	HikePriceGel     int32
+	UserID           int32
+	UserName         string
+	UserUsername     string
+	UserTgID         int64
+	UserLang         string
+	Status           BookingStatus
+	TakenByAdminID   *int32
+	TakenAt          *time.Time
+	AdminName        string
+	AdminUsername    string
+	AdminTgID        int64
+	AdminMessageID   int
+	CancelReason     string
+	WaitlistPosition int64
+	// Rebooked is set by Create when the client had an earlier, no longer
+	// active booking on the same hike.
+	Rebooked  bool
+	CreatedAt time.Time
+}
+
+// HikeTitleIn returns the hike title in the language, falling back to Russian.
+func (b Booking) HikeTitleIn(lang string) string {
+	return i18n.Localized(lang, b.HikeTitle, b.HikeTitleEn)
 }
 
 type Repository interface {
 	GetByID(ctx context.Context, id int32) (Booking, error)
-	Create(ctx context.Context, booking Booking) (int32, error)
+	Create(ctx context.Context, booking Booking) (Booking, error)
 	TakeInProgress(ctx context.Context, bookingID, adminID int32) (int32, error)
+	GetDetails(ctx context.Context, id int32) (Booking, error)
+	ListByUser(ctx context.Context, userID int32) ([]Booking, error)
+	Cancel(ctx context.Context, bookingID, userID int32, reason string) (int32, error)
+	SetAdminMessageID(ctx context.Context, bookingID int32, messageID int) error
+	CreatePayment(ctx context.Context, payment Payment) (int32, error)
+}
+
+type Notifier interface {
+	NotifyCanceledByClient(ctx context.Context, booking Booking) error
+	NotifyTaken(ctx context.Context, booking Booking) error
+	// NotifyPaymentSubmitted forwards the receipt to the manager who took the booking.
+	NotifyPaymentSubmitted(ctx context.Context, booking Booking, payment Payment) error
 }
 
 type Service interface {
 	GetByID(ctx context.Context, id int32) (Booking, error)
-	Create(ctx context.Context, hikeID, userID int32) (int32, error)
+	Create(ctx context.Context, hikeID, userID int32) (Booking, error)
 	TakeInProgress(ctx context.Context, bookingID, adminID int32) (int32, error)
+	// NotifyTaken tells the client which manager took their booking.
+	NotifyTaken(ctx context.Context, bookingID int32) error
+	ListUserBookings(ctx context.Context, userID int32) ([]Booking, error)
+	Cancel(ctx context.Context, bookingID, userID int32, reason string) error
+	SetAdminMessageID(ctx context.Context, bookingID int32, messageID int) error
+	SubmitPayment(ctx context.Context, bookingID, userID int32, amount float64, proofFileID string) (Payment, error)
+}
+
+// Waitlist gives the seat freed by a canceled booking to waitlisted clients.
+type Waitlist interface {
+	Promote(ctx context.Context, hikeID int32) error
 }
 
 type service struct {
-	repo Repository
+	repo     Repository
+	notifier Notifier
+	waitlist Waitlist
 }
 
-func New(r Repository) Service {
-	return &service{repo: r}
+func New(r Repository, n Notifier, w Waitlist) Service {
+	return &service{repo: r, notifier: n, waitlist: w}
 }
 
 func (s *service) GetByID(ctx context.Context, id int32) (Booking, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *service) Create(ctx context.Context, hikeID, userID int32) (int32, error) {
+// Create books a seat on the hike, or puts the client on the waitlist
+// when the hike is full. The returned booking carries the resulting status.
+// If the client already has an active booking on the hike, it is returned
+// together with ErrBookingAlreadyExists.
+func (s *service) Create(ctx context.Context, hikeID, userID int32) (Booking, error) {
 	booking := Booking{
 		HikeID: hikeID,
 		UserID: userID,
@@ -78,3 +150,89 @@ func (s *service) Create(ctx context.Context, hikeID, userID int32) (int32, erro
 func (s *service) TakeInProgress(ctx context.Context, bookingID, adminID int32) (int32, error) {
 	return s.repo.TakeInProgress(ctx, bookingID, adminID)
 }
+
+func (s *service) NotifyTaken(ctx context.Context, bookingID int32) error {
+	booking, err := s.repo.GetDetails(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.NotifyTaken(ctx, booking)
+}
+
+func (s *service) ListUserBookings(ctx context.Context, userID int32) ([]Booking, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// Cancel withdraws an active booking of the user. The seat goes to the
+// next waitlisted client, and the admin chat and the assigned manager
+// are told about the reason. Failed side effects are returned wrapped in
+// ErrWaitlistPromotion / ErrCancelNotification, the booking stays canceled.
+func (s *service) Cancel(ctx context.Context, bookingID, userID int32, reason string) error {
+	hikeID, err := s.repo.Cancel(ctx, bookingID, userID, reason)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+
+	if err := s.waitlist.Promote(ctx, hikeID); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrWaitlistPromotion, err))
+	}
+
+	if err := s.notifyCanceled(ctx, bookingID); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrCancelNotification, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *service) SetAdminMessageID(ctx context.Context, bookingID int32, messageID int) error {
+	return s.repo.SetAdminMessageID(ctx, bookingID, messageID)
+}
+
+// SubmitPayment records a payment receipt for a confirmed booking of the user
+// and sends it to the assigned manager for review. A zero amount means the
+// full hike price. If only the notification fails, the payment is returned
+// with an error wrapping ErrPaymentNotification.
+func (s *service) SubmitPayment(ctx context.Context, bookingID, userID int32, amount float64, proofFileID string) (Payment, error) {
+	booking, err := s.repo.GetDetails(ctx, bookingID)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if booking.UserID != userID || booking.Status != StatusConfirmed {
+		return Payment{}, ErrPaymentNotAllowed
+	}
+
+	if amount <= 0 {
+		amount = float64(booking.HikePriceGel)
+	}
+
+	payment := Payment{
+		BookingID:   bookingID,
+		Amount:      amount,
+		Currency:    PaymentCurrency,
+		ProofFileID: proofFileID,
+	}
+
+	payment.ID, err = s.repo.CreatePayment(ctx, payment)
+	if err != nil {
+		return Payment{}, err
+	}
+
+	if err := s.notifier.NotifyPaymentSubmitted(ctx, booking, payment); err != nil {
+		return payment, fmt.Errorf("%w: %w", ErrPaymentNotification, err)
+	}
+
+	return payment, nil
+}
+
+func (s *service) notifyCanceled(ctx context.Context, bookingID int32) error {
+	booking, err := s.repo.GetDetails(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+
+	return s.notifier.NotifyCanceledByClient(ctx, booking)
+}
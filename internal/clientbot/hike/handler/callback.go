@@ -6,11 +6,15 @@ import (
 	"html"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
 	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/hike"
+	reviewUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/review"
 )
 
 func (h *Handler) DetailsHike(ctx context.Context, q *tgbot.CallbackQuery) error {
@@ -29,13 +33,24 @@ func (h *Handler) DetailsHike(ctx context.Context, q *tgbot.CallbackQuery) error
 		return logger.WrapError(err)
 	}
 
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
 	text := fmt.Sprintf(
 		"<b>%s</b>\n\n%s",
-		html.EscapeString(hike.TitleRu),
-		html.EscapeString(hike.DescriptionRu),
+		html.EscapeString(hike.Title(lang)),
+		html.EscapeString(hike.Description(lang)),
 	)
 
-	kb := hikeUI.DetailsHikeActions(hike)
+	// The details are still worth showing without the rating
+	rating, ratingErr := h.reviewService.GetHikeRating(ctx, hike.ID)
+	if block := reviewUI.HikeRatingBlock(rating, lang); ratingErr == nil && block != "" {
+		text += "\n\n" + block
+	}
+
+	kb := hikeUI.DetailsHikeActions(hike, lang)
 
 	msg := tgbot.NewMessage(q.Message.Chat.ID, text)
 	msg.ParseMode = tgbot.ModeHTML
@@ -45,5 +60,131 @@ func (h *Handler) DetailsHike(ctx context.Context, q *tgbot.CallbackQuery) error
 		return logger.WrapError(err)
 	}
 
-	return nil
+	return ratingErr
+}
+
+// ShowCatalogPage scrolls the catalog carousel or switches its month,
+// editing the message in place.
+func (h *Handler) ShowCatalogPage(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	if q.Data == "catalog:noop" {
+		return h.replyCallback(q, "")
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	filter := h.filter(q.From.ID)
+	pos := int32(1)
+
+	parts := strings.Split(q.Data, ":")
+	if len(parts) != 3 {
+		return h.replyCallback(q, "")
+	}
+
+	switch parts[1] {
+	case "page":
+		p, err := strconv.ParseInt(parts[2], 10, 32)
+		if err != nil {
+			return h.replyCallback(q, "")
+		}
+		pos = int32(p)
+
+	case "month":
+		var month time.Time
+		if parts[2] != hikeUI.CatalogAllMonths {
+			month, err = time.ParseInLocation(hikeUI.CatalogMonthLayout, parts[2], h.loc)
+			if err != nil {
+				return h.replyCallback(q, "")
+			}
+		}
+		filter = filter.WithMonth(month)
+
+	default:
+		return h.replyCallback(q, "")
+	}
+
+	page, err := h.service.GetCatalogPage(ctx, filter, pos)
+	if err != nil {
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientError))
+		return err
+	}
+
+	if page.Total == 0 {
+		if parts[1] == "month" {
+			return h.replyCallback(q, i18n.T(lang, i18n.ClientCatalogMonthEmpty))
+		}
+		return h.replyCallback(q, i18n.T(lang, i18n.ClientCatalogNothingFound))
+	}
+
+	h.setFilter(q.From.ID, page.Filter)
+
+	months, err := h.service.ListCatalogMonths(ctx, page.Filter, h.loc)
+	if err != nil {
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientError))
+		return err
+	}
+
+	if err := h.replyCallback(q, ""); err != nil {
+		return err
+	}
+
+	return h.editCatalogPage(q.Message, page, months, lang)
+}
+
+// editCatalogPage shows the page in the carousel message.
+func (h *Handler) editCatalogPage(m *tgbot.Message, page service.CatalogPage, months []time.Time, lang string) error {
+	chatID, messageID := m.Chat.ID, m.MessageID
+	caption := buildHikeCaption(page.Hike, lang)
+	kb := hikeUI.CatalogKeyboard(page, months, lang)
+	imagePath, hasImage := h.hikeImagePath(page.Hike)
+
+	switch {
+	case hasImage && len(m.Photo) > 0:
+		media := tgbot.NewInputMediaPhoto(tgbot.FilePath(imagePath))
+		media.Caption = caption
+		media.ParseMode = tgbot.ModeHTML
+
+		edit := tgbot.EditMessageMediaConfig{
+			BaseEdit: tgbot.BaseEdit{
+				ChatID:      chatID,
+				MessageID:   messageID,
+				ReplyMarkup: &kb,
+			},
+			Media: media,
+		}
+
+		_, err := h.bot.Send(edit)
+		return logger.WrapError(err)
+
+	case !hasImage && len(m.Photo) == 0:
+		edit := tgbot.NewEditMessageTextAndMarkup(chatID, messageID, caption, kb)
+		edit.ParseMode = tgbot.ModeHTML
+
+		_, err := h.bot.Send(edit)
+		return logger.WrapError(err)
+	}
+
+	// A text message can't become a photo and back, so the carousel moves
+	// to a new message.
+	if _, err := h.bot.Request(tgbot.NewDeleteMessage(chatID, messageID)); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.sendCatalogPage(chatID, page, months, lang)
+}
+
+func (h *Handler) replyCallback(q *tgbot.CallbackQuery, text string) error {
+	cfg := tgbot.CallbackConfig{
+		CallbackQueryID: q.ID,
+		Text:            text,
+	}
+	_, err := h.bot.Request(cfg)
+
+	return err
 }
@@ -0,0 +1,273 @@
+package handler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/hike"
+)
+
+// Filter fields the client types a value for
+const (
+	filterInputDates = "dates"
+	filterInputPrice = "price"
+	filterInputQuery = "query"
+)
+
+// filterQueryLimit keeps search queries short, they are matched as words anyway.
+const filterQueryLimit = 100
+
+// HandleFilterCallback handles the buttons of the catalog filters,
+// catalog_filter:<action>.
+func (h *Handler) HandleFilterCallback(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	action := strings.TrimPrefix(q.Data, "catalog_filter:")
+	filter := h.filter(q.From.ID)
+
+	switch {
+	case action == "open":
+		if err := h.replyCallback(q, ""); err != nil {
+			return err
+		}
+		return h.sendFilters(ctx, q.From.ID, q.Message.Chat.ID, lang)
+
+	case strings.HasPrefix(action, "difficulty:"):
+		filter.Difficulty = trail.Difficulty(strings.TrimPrefix(action, "difficulty:"))
+		h.setFilter(q.From.ID, filter)
+		return h.refreshFilters(ctx, q, lang)
+
+	case strings.HasPrefix(action, "tag:"):
+		filter.Tag = trail.Tag(strings.TrimPrefix(action, "tag:"))
+		h.setFilter(q.From.ID, filter)
+		return h.refreshFilters(ctx, q, lang)
+
+	case action == "reset":
+		h.setFilter(q.From.ID, service.Filter{})
+		return h.refreshFilters(ctx, q, lang)
+
+	case action == filterInputDates, action == filterInputPrice, action == filterInputQuery:
+		h.setFilterInput(q.From.ID, action)
+
+		prompts := map[string]i18n.Key{
+			filterInputDates: i18n.ClientFilterDatesPrompt,
+			filterInputPrice: i18n.ClientFilterPricePrompt,
+			filterInputQuery: i18n.ClientFilterQueryPrompt,
+		}
+
+		msg := tgbot.NewMessage(q.Message.Chat.ID, i18n.T(lang, prompts[action]))
+		msg.ReplyMarkup = hikeUI.FilterInputKeyboard(lang)
+		if _, err := h.bot.Send(msg); err != nil {
+			return logger.WrapError(err)
+		}
+
+		return h.replyCallback(q, "")
+
+	case action == "abort":
+		h.takeFilterInput(q.From.ID)
+
+		if _, err := h.bot.Request(tgbot.NewDeleteMessage(q.Message.Chat.ID, q.Message.MessageID)); err != nil {
+			return logger.WrapError(err)
+		}
+
+		return h.replyCallback(q, "")
+
+	case action == "show":
+		if err := h.replyCallback(q, ""); err != nil {
+			return err
+		}
+		return h.showCatalog(ctx, q.From.ID, q.Message.Chat.ID, lang)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) AwaitingFilterInput(tgUserID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, ok := h.filterInputs[tgUserID]
+	return ok
+}
+
+// DropAwaiting forgets the filter value the client was asked for.
+func (h *Handler) DropAwaiting(tgUserID int64) {
+	h.takeFilterInput(tgUserID)
+}
+
+// HandleFilterInput takes the filter value typed after the filter button,
+// "-" clears the filter.
+func (h *Handler) HandleFilterInput(ctx context.Context, m *tgbot.Message) error {
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	field := h.filterInputs[m.From.ID]
+	h.mu.Unlock()
+
+	filter := h.filter(m.From.ID)
+	text := strings.TrimSpace(m.Text)
+	reset := text == "-"
+
+	switch field {
+	case filterInputDates:
+		if reset {
+			filter = filter.WithMonth(time.Time{})
+			break
+		}
+
+		from, before, ok := parseDateRange(text, h.loc)
+		if !ok {
+			_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientFilterBadDates)))
+			return err
+		}
+		filter.StartsFrom, filter.StartsBefore = from, before
+
+	case filterInputPrice:
+		if reset {
+			filter.MaxPriceGel = 0
+			break
+		}
+
+		price, err := strconv.Atoi(text)
+		if err != nil || price <= 0 {
+			_, err := h.bot.Send(tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientFilterBadPrice)))
+			return err
+		}
+		filter.MaxPriceGel = int32(price)
+
+	case filterInputQuery:
+		if reset {
+			filter.Query = ""
+			break
+		}
+
+		if utf8.RuneCountInString(text) > filterQueryLimit {
+			text = string([]rune(text)[:filterQueryLimit])
+		}
+		filter.Query = text
+	}
+
+	h.takeFilterInput(m.From.ID)
+	h.setFilter(m.From.ID, filter)
+
+	return h.sendFilters(ctx, m.From.ID, m.Chat.ID, lang)
+}
+
+func (h *Handler) sendFilters(ctx context.Context, tgUserID, chatID int64, lang string) error {
+	filter := h.filter(tgUserID)
+
+	page, err := h.service.GetCatalogPage(ctx, filter, 1)
+	if err != nil {
+		return err
+	}
+
+	msg := tgbot.NewMessage(chatID, hikeUI.FiltersMessage(filter, page.Total, lang))
+	msg.ParseMode = tgbot.ModeHTML
+	msg.ReplyMarkup = hikeUI.FiltersKeyboard(filter, lang)
+
+	_, err = h.bot.Send(msg)
+	return logger.WrapError(err)
+}
+
+// refreshFilters edits the filters message after a change made with its buttons.
+func (h *Handler) refreshFilters(ctx context.Context, q *tgbot.CallbackQuery, lang string) error {
+	filter := h.filter(q.From.ID)
+
+	page, err := h.service.GetCatalogPage(ctx, filter, 1)
+	if err != nil {
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientError))
+		return err
+	}
+
+	edit := tgbot.NewEditMessageTextAndMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		hikeUI.FiltersMessage(filter, page.Total, lang),
+		hikeUI.FiltersKeyboard(filter, lang),
+	)
+	edit.ParseMode = tgbot.ModeHTML
+
+	if _, err := h.bot.Send(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) filter(tgUserID int64) service.Filter {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.filters[tgUserID]
+}
+
+func (h *Handler) setFilter(tgUserID int64, filter service.Filter) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if filter.IsZero() {
+		delete(h.filters, tgUserID)
+		return
+	}
+	h.filters[tgUserID] = filter
+}
+
+func (h *Handler) setFilterInput(tgUserID int64, field string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.filterInputs[tgUserID] = field
+}
+
+func (h *Handler) takeFilterInput(tgUserID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.filterInputs, tgUserID)
+}
+
+// parseDateRange reads "02.01.2006" or "02.01.2006 - 02.01.2006" and returns
+// the start of the first day and the start of the day after the last one.
+func parseDateRange(text string, loc *time.Location) (from, before time.Time, ok bool) {
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == '-' || r == '–' || r == '—'
+	})
+	if len(parts) == 0 || len(parts) > 2 {
+		return time.Time{}, time.Time{}, false
+	}
+
+	dates := make([]time.Time, 0, 2)
+	for _, p := range parts {
+		d, err := time.ParseInLocation("02.01.2006", strings.TrimSpace(p), loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, false
+		}
+		dates = append(dates, d)
+	}
+
+	from, last := dates[0], dates[len(dates)-1]
+	if last.Before(from) {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return from, last.AddDate(0, 0, 1), true
+}
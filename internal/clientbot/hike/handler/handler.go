@@ -1,21 +1,48 @@
 package handler
 
 import (
+	"sync"
+	"time"
+
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/config"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	reviewService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/service"
+	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
 )
 
 type Handler struct {
-	bot     *tgbot.BotAPI
-	cfg     config.ClientBot
-	service service.Service
+	bot           *tgbot.BotAPI
+	cfg           config.ClientBot
+	service       service.Service
+	reviewService reviewService.Service
+	userService   userService.Service
+	loc           *time.Location
+
+	// filters hold the catalog filter of a client and filterInputs the
+	// filter field the client is typing a value for, by tg user ID
+	mu           sync.Mutex
+	filters      map[int64]service.Filter
+	filterInputs map[int64]string
 }
 
-func New(b *tgbot.BotAPI, c config.ClientBot, s service.Service) *Handler {
+func New(
+	b *tgbot.BotAPI,
+	c config.ClientBot,
+	s service.Service,
+	rS reviewService.Service,
+	uS userService.Service,
+	l *time.Location,
+) *Handler {
 	return &Handler{
-		bot:     b,
-		cfg:     c,
-		service: s,
+		bot:           b,
+		cfg:           c,
+		service:       s,
+		reviewService: rS,
+		userService:   uS,
+		loc:           l,
+		filters:       make(map[int64]service.Filter),
+		filterInputs:  make(map[int64]string),
 	}
 }
@@ -6,7 +6,10 @@ import (
 	"html"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
@@ -14,60 +17,86 @@ import (
 	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/hike"
 )
 
+// ListActualHikes opens the catalog carousel on the first hike matching the
+// filter of the client, the client scrolls it with the buttons of the same message.
 func (h *Handler) ListActualHikes(ctx context.Context, m *tgbot.Message) error {
-	rows, err := h.service.ListActualHikes(ctx, 1, 20)
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
 
+	return h.showCatalog(ctx, m.From.ID, m.Chat.ID, lang)
+}
+
+func (h *Handler) showCatalog(ctx context.Context, tgUserID, chatID int64, lang string) error {
+	filter := h.filter(tgUserID)
+
+	page, err := h.service.GetCatalogPage(ctx, filter, 1)
 	if err != nil {
 		return err
 	}
 
-	if len(rows) == 0 {
-		_, err = h.bot.Send(tgbot.NewMessage(m.Chat.ID, "Пока нет актуальных хайков."))
+	if page.Total == 0 && filter.IsZero() {
+		_, err = h.bot.Send(tgbot.NewMessage(chatID, i18n.T(lang, i18n.ClientHikesEmpty)))
 		return err
 	}
 
-	for _, r := range rows {
-		caption := buildHikeCaption(r)
+	months, err := h.service.ListCatalogMonths(ctx, filter, h.loc)
+	if err != nil {
+		return err
+	}
 
-		kb := hikeUI.PreviewHikeActions(r)
+	if page.Total == 0 {
+		msg := tgbot.NewMessage(chatID, i18n.T(lang, i18n.ClientCatalogNothingFound))
+		msg.ReplyMarkup = hikeUI.CatalogKeyboard(page, months, lang)
 
-		if r.ImagePath != nil && *r.ImagePath != "" {
-			imagePath := filepath.Join(h.cfg.StorageRoot, *r.ImagePath)
-			msg := tgbot.NewPhoto(m.Chat.ID, tgbot.FilePath(imagePath))
-			msg.Caption = caption
-			msg.ParseMode = tgbot.ModeHTML
-			msg.ReplyMarkup = kb
+		_, err = h.bot.Send(msg)
+		return logger.WrapError(err)
+	}
 
-			if _, err := h.bot.Send(msg); err != nil {
-				return logger.WrapError(err)
-			}
+	return h.sendCatalogPage(chatID, page, months, lang)
+}
 
-			continue
-		}
+func (h *Handler) sendCatalogPage(chatID int64, page service.CatalogPage, months []time.Time, lang string) error {
+	caption := buildHikeCaption(page.Hike, lang)
+	kb := hikeUI.CatalogKeyboard(page, months, lang)
 
-		msg := tgbot.NewMessage(m.Chat.ID, caption)
+	if imagePath, ok := h.hikeImagePath(page.Hike); ok {
+		msg := tgbot.NewPhoto(chatID, tgbot.FilePath(imagePath))
+		msg.Caption = caption
 		msg.ParseMode = tgbot.ModeHTML
 		msg.ReplyMarkup = kb
 
-		if _, err := h.bot.Send(msg); err != nil {
-			return logger.WrapError(err)
-		}
+		_, err := h.bot.Send(msg)
+		return logger.WrapError(err)
 	}
 
-	return nil
+	msg := tgbot.NewMessage(chatID, caption)
+	msg.ParseMode = tgbot.ModeHTML
+	msg.ReplyMarkup = kb
+
+	_, err := h.bot.Send(msg)
+	return logger.WrapError(err)
+}
+
+func (h *Handler) hikeImagePath(hike service.Hike) (string, bool) {
+	if hike.ImagePath == nil || *hike.ImagePath == "" {
+		return "", false
+	}
+	return filepath.Join(h.cfg.StorageRoot, *hike.ImagePath), true
 }
 
-func buildHikeCaption(hike service.Hike) string {
+func buildHikeCaption(hike service.Hike, lang string) string {
 	var b strings.Builder
 
 	// Title
 	b.WriteString("🏔 <b>")
-	b.WriteString(html.EscapeString(hike.TitleRu))
+	b.WriteString(html.EscapeString(hike.Title(lang)))
 	b.WriteString("</b>\n")
 
 	// Dates
 	b.WriteString("🗓 ")
-	b.WriteString(hikeUI.FormatDateRange(hike.StartsAt, hike.EndsAt))
+	b.WriteString(hikeUI.FormatDateRange(hike.StartsAt, hike.EndsAt, lang))
 	b.WriteString("\n")
 
 	// Meta
@@ -78,11 +107,11 @@ func buildHikeCaption(hike service.Hike) string {
 	}
 
 	if hike.DistanceKm > 0 {
-		meta = append(meta, fmt.Sprintf("🥾 %.1f км", hike.DistanceKm))
+		meta = append(meta, i18n.T(lang, i18n.ClientHikeDistance, hike.DistanceKm))
 	}
 
 	if hike.ElevationGainM > 0 {
-		meta = append(meta, fmt.Sprintf("⛰ %d м набор", hike.ElevationGainM))
+		meta = append(meta, i18n.T(lang, i18n.ClientHikeElevationGain, hike.ElevationGainM))
 	}
 
 	if len(meta) > 0 {
@@ -91,10 +120,29 @@ func buildHikeCaption(hike service.Hike) string {
 		b.WriteString("\n")
 	}
 
+	// Difficulty and tags
+	var marks []string
+
+	if hike.Difficulty != "" {
+		marks = append(marks, trail.DifficultyLabel(hike.Difficulty, lang))
+	}
+
+	for _, tag := range hike.Tags {
+		marks = append(marks, trail.TagLabel(tag, lang))
+	}
+
+	if len(marks) > 0 {
+		if len(meta) == 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(strings.Join(marks, " • "))
+		b.WriteString("\n")
+	}
+
 	// Preview field
-	if hike.PreviewRu != "" {
+	if preview := hike.Preview(lang); preview != "" {
 		b.WriteString("\n")
-		b.WriteString(html.EscapeString(hike.PreviewRu))
+		b.WriteString(html.EscapeString(preview))
 	}
 
 	return b.String()
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type repository struct {
@@ -18,10 +21,16 @@ func New(q *client.Queries) service.Repository {
 	return &repository{queries: q}
 }
 
-func (r *repository) ListActualHikes(ctx context.Context, limit, offset int32) ([]service.Hike, error) {
+func (r *repository) ListActualHikes(ctx context.Context, filter service.Filter, limit, offset int32) ([]service.Hike, error) {
 	rawHikes, err := r.queries.ListActualHikes(ctx, client.ListActualHikesParams{
-		Limit:  limit,
-		Offset: offset,
+		StartsFrom:   toPgTimestamptz(filter.StartsFrom),
+		StartsBefore: toPgTimestamptz(filter.StartsBefore),
+		MaxPrice:     toPgInt4(filter.MaxPriceGel),
+		Difficulty:   toPgText(string(filter.Difficulty)),
+		Tag:          toPgText(string(filter.Tag)),
+		Query:        toPgText(filter.Query),
+		Limit:        limit,
+		Offset:       offset,
 	})
 	if err != nil {
 		return nil, logger.WrapError(err)
@@ -48,18 +57,50 @@ func (r *repository) ListActualHikes(ctx context.Context, limit, offset int32) (
 			ID:             rawHike.ID,
 			TitleRu:        rawHike.TitleRu,
 			PreviewRu:      rawHike.PreviewRu,
+			TitleEn:        rawHike.TitleEn.String,
+			PreviewEn:      rawHike.PreviewEn.String,
 			StartsAt:       rawHike.StartsAt,
 			EndsAt:         rawHike.EndsAt,
 			ImagePath:      imagePath,
 			PriceGel:       rawHike.PriceGel,
 			DistanceKm:     distance,
 			ElevationGainM: int(rawHike.ElevationGainM.Int32),
+			Difficulty:     trail.Difficulty(rawHike.Difficulty),
+			Tags:           trail.ParseTags(rawHike.Tags),
 		})
 	}
 
 	return serviceHikes, nil
 }
 
+func (r *repository) CountActualHikes(ctx context.Context, filter service.Filter) (int64, error) {
+	count, err := r.queries.CountActualHikes(ctx, client.CountActualHikesParams{
+		StartsFrom:   toPgTimestamptz(filter.StartsFrom),
+		StartsBefore: toPgTimestamptz(filter.StartsBefore),
+		MaxPrice:     toPgInt4(filter.MaxPriceGel),
+		Difficulty:   toPgText(string(filter.Difficulty)),
+		Tag:          toPgText(string(filter.Tag)),
+		Query:        toPgText(filter.Query),
+	})
+	if err != nil {
+		return 0, logger.WrapError(err)
+	}
+	return count, nil
+}
+
+func (r *repository) ListActualHikeStarts(ctx context.Context, filter service.Filter) ([]time.Time, error) {
+	starts, err := r.queries.ListActualHikeStarts(ctx, client.ListActualHikeStartsParams{
+		MaxPrice:   toPgInt4(filter.MaxPriceGel),
+		Difficulty: toPgText(string(filter.Difficulty)),
+		Tag:        toPgText(string(filter.Tag)),
+		Query:      toPgText(filter.Query),
+	})
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+	return starts, nil
+}
+
 func (r *repository) GetHike(ctx context.Context, id int32) (service.Hike, error) {
 	hikeRaw, err := r.queries.GetHike(ctx, id)
 	if err != nil {
@@ -72,9 +113,32 @@ func (r *repository) GetHike(ctx context.Context, id int32) (service.Hike, error
 		ID:            hikeRaw.ID,
 		TitleRu:       hikeRaw.TitleRu,
 		DescriptionRu: hikeRaw.DescriptionRu,
+		TitleEn:       hikeRaw.TitleEn.String,
+		DescriptionEn: hikeRaw.DescriptionEn.String,
 		StartsAt:      hikeRaw.StartsAt,
 		EndsAt:        hikeRaw.EndsAt,
 	}
 
 	return hike, nil
 }
+
+func toPgTimestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
+}
+
+func toPgInt4(i int32) pgtype.Int4 {
+	return pgtype.Int4{
+		Int32: i,
+		Valid: i != 0,
+	}
+}
+
+func toPgText(s string) pgtype.Text {
+	return pgtype.Text{
+		String: s,
+		Valid:  s != "",
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// CatalogPage is one hike of the catalog carousel.
+type CatalogPage struct {
+	Hike   Hike
+	Filter Filter
+	// Pos is 1-based, Total is 0 when no hike matches the filter.
+	Pos   int32
+	Total int32
+}
+
+// Prev and Next wrap around, so the carousel can be scrolled endlessly.
+func (p CatalogPage) Prev() int32 {
+	if p.Pos <= 1 {
+		return p.Total
+	}
+	return p.Pos - 1
+}
+
+func (p CatalogPage) Next() int32 {
+	if p.Pos >= p.Total {
+		return 1
+	}
+	return p.Pos + 1
+}
+
+func (s *service) GetCatalogPage(ctx context.Context, filter Filter, pos int32) (CatalogPage, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if !filter.Difficulty.Valid() {
+		filter.Difficulty = ""
+	}
+	if !filter.Tag.Valid() {
+		filter.Tag = ""
+	}
+
+	total, err := s.repo.CountActualHikes(ctx, filter)
+	if err != nil {
+		return CatalogPage{}, err
+	}
+
+	page := CatalogPage{Filter: filter, Total: int32(total)}
+	if total == 0 {
+		return page, nil
+	}
+
+	// The catalog may have shrunk since the client opened the page.
+	page.Pos = max(min(pos, page.Total), 1)
+
+	hikes, err := s.repo.ListActualHikes(ctx, filter, 1, page.Pos-1)
+	if err != nil {
+		return CatalogPage{}, err
+	}
+	if len(hikes) == 0 {
+		return CatalogPage{}, ErrHikesNotFound
+	}
+
+	page.Hike = hikes[0]
+	return page, nil
+}
+
+func (s *service) ListCatalogMonths(ctx context.Context, filter Filter, loc *time.Location) ([]time.Time, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	starts, err := s.repo.ListActualHikeStarts(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var months []time.Time
+	for _, t := range starts {
+		t = t.In(loc)
+		month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		if len(months) == 0 || !months[len(months)-1].Equal(month) {
+			months = append(months, month)
+		}
+	}
+
+	return months, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+)
+
+// Filter narrows the catalog, zero fields don't filter.
+type Filter struct {
+	// StartsFrom and StartsBefore bound the start time of the hikes.
+	StartsFrom   time.Time
+	StartsBefore time.Time
+	MaxPriceGel  int32
+	Difficulty   trail.Difficulty
+	// Tag keeps the hikes marked with it.
+	Tag trail.Tag
+	// Query is searched in the titles, previews and descriptions in both
+	// languages, see websearch_to_tsquery for the syntax.
+	Query string
+}
+
+func (f Filter) IsZero() bool {
+	return f == Filter{}
+}
+
+// Active counts the filters set, dates count once.
+func (f Filter) Active() int {
+	n := 0
+	if !f.StartsFrom.IsZero() || !f.StartsBefore.IsZero() {
+		n++
+	}
+	if f.MaxPriceGel > 0 {
+		n++
+	}
+	if f.Difficulty != "" {
+		n++
+	}
+	if f.Tag != "" {
+		n++
+	}
+	if strings.TrimSpace(f.Query) != "" {
+		n++
+	}
+	return n
+}
+
+// WithMonth limits the hikes to the month starting at the given first day,
+// a zero month drops the date limits.
+func (f Filter) WithMonth(month time.Time) Filter {
+	if month.IsZero() {
+		f.StartsFrom, f.StartsBefore = time.Time{}, time.Time{}
+		return f
+	}
+	f.StartsFrom, f.StartsBefore = month, month.AddDate(0, 1, 0)
+	return f
+}
+
+// Month returns the first day of the month the filter is limited to, if
+// the dates cover exactly one calendar month.
+func (f Filter) Month() (time.Time, bool) {
+	if f.StartsFrom.IsZero() || f.StartsFrom.Day() != 1 || f.StartsFrom.Hour() != 0 || f.StartsFrom.Minute() != 0 {
+		return time.Time{}, false
+	}
+	if !f.StartsBefore.Equal(f.StartsFrom.AddDate(0, 1, 0)) {
+		return time.Time{}, false
+	}
+	return f.StartsFrom, true
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
 )
 
 type Hike struct {
@@ -11,12 +14,32 @@ type Hike struct {
 	TitleRu        string
 	PreviewRu      string
 	DescriptionRu  string
+	TitleEn        string
+	PreviewEn      string
+	DescriptionEn  string
 	StartsAt       time.Time
 	EndsAt         time.Time
 	ImagePath      *string
 	PriceGel       int32
 	DistanceKm     float64
 	ElevationGainM int
+	Difficulty     trail.Difficulty
+	Tags           []trail.Tag
+}
+
+// Title returns the hike title in the language, falling back to Russian.
+func (h Hike) Title(lang string) string {
+	return i18n.Localized(lang, h.TitleRu, h.TitleEn)
+}
+
+// Preview returns the hike preview in the language, falling back to Russian.
+func (h Hike) Preview(lang string) string {
+	return i18n.Localized(lang, h.PreviewRu, h.PreviewEn)
+}
+
+// Description returns the hike description in the language, falling back to Russian.
+func (h Hike) Description(lang string) string {
+	return i18n.Localized(lang, h.DescriptionRu, h.DescriptionEn)
 }
 
 var (
@@ -25,12 +48,21 @@ var (
 
 type Repository interface {
 	GetHike(ctx context.Context, id int32) (Hike, error)
-	ListActualHikes(ctx context.Context, limit, offset int32) ([]Hike, error)
+	ListActualHikes(ctx context.Context, filter Filter, limit, offset int32) ([]Hike, error)
+	CountActualHikes(ctx context.Context, filter Filter) (int64, error)
+	// ListActualHikeStarts returns the start times of actual hikes matching
+	// the filter in order, ignoring its dates.
+	ListActualHikeStarts(ctx context.Context, filter Filter) ([]time.Time, error)
 }
 
 type Service interface {
 	GetHike(ctx context.Context, id int32) (Hike, error)
-	ListActualHikes(ctx context.Context, page, size int32) ([]Hike, error)
+	// GetCatalogPage returns the actual hike at pos among those matching the filter.
+	GetCatalogPage(ctx context.Context, filter Filter, pos int32) (CatalogPage, error)
+	// ListCatalogMonths returns the first days of the months in loc that
+	// have actual hikes matching the filter starting in them, in order.
+	// The dates of the filter are ignored.
+	ListCatalogMonths(ctx context.Context, filter Filter, loc *time.Location) ([]time.Time, error)
 }
 
 type service struct {
@@ -41,11 +73,6 @@ func New(r Repository) Service {
 	return &service{repo: r}
 }
 
-func (s *service) ListActualHikes(ctx context.Context, page, size int32) ([]Hike, error) {
-	offset := (page - 1) * size
-	return s.repo.ListActualHikes(ctx, size, offset)
-}
-
 func (s *service) GetHike(ctx context.Context, id int32) (Hike, error) {
 	return s.repo.GetHike(ctx, id)
 }
@@ -0,0 +1,33 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/notify"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/reminder/service"
+)
+
+var templates = map[service.Kind]notify.Template{
+	service.Kind48h: notify.HikeReminder48h,
+	service.Kind3h:  notify.HikeReminder3h,
+}
+
+type notifier struct {
+	notify notify.Service
+}
+
+func New(n notify.Service) service.Notifier {
+	return &notifier{notify: n}
+}
+
+func (n *notifier) NotifyReminder(ctx context.Context, kind service.Kind, r service.Reminder) error {
+	return n.notify.Send(ctx, r.UserTgID, r.UserLang, templates[kind], notify.Booking{
+		ID:              r.BookingID,
+		HikeTitle:       i18n.Localized(r.UserLang, r.HikeTitle, r.HikeTitleEn),
+		HikeStartsAt:    r.HikeStartsAt,
+		ManagerName:     r.AdminName,
+		ManagerUsername: r.AdminUsername,
+	})
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/reminder/service"
+)
+
+type repository struct {
+	queries *client.Queries
+}
+
+func New(q *client.Queries) service.Repository {
+	return &repository{queries: q}
+}
+
+func (r *repository) ListDue(ctx context.Context, kind service.Kind, startsAfter, startsBefore time.Time) ([]service.Reminder, error) {
+	rows, err := r.queries.ListDueReminders(ctx, client.ListDueRemindersParams{
+		StartsAfter:  startsAfter,
+		StartsBefore: startsBefore,
+		Kind:         string(kind),
+	})
+	if err != nil {
+		return nil, logger.WrapError(err)
+	}
+
+	reminders := make([]service.Reminder, 0, len(rows))
+	for _, row := range rows {
+		reminders = append(reminders, service.Reminder{
+			BookingID:     row.BookingID,
+			HikeTitle:     row.HikeTitle,
+			HikeTitleEn:   row.HikeTitleEn,
+			HikeStartsAt:  row.HikeStartsAt,
+			UserTgID:      row.UserTgID,
+			UserLang:      row.UserLang,
+			AdminName:     row.AdminName,
+			AdminUsername: row.AdminUsername,
+		})
+	}
+
+	return reminders, nil
+}
+
+func (r *repository) MarkSent(ctx context.Context, bookingID int32, kind service.Kind) (bool, error) {
+	inserted, err := r.queries.MarkReminderSent(ctx, client.MarkReminderSentParams{
+		BookingID: bookingID,
+		Kind:      string(kind),
+	})
+	if err != nil {
+		return false, logger.WrapError(err)
+	}
+
+	return inserted > 0, nil
+}
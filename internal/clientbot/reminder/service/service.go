@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type Kind string
+
+const (
+	Kind48h Kind = "48h"
+	Kind3h  Kind = "3h"
+)
+
+// schedule lists reminders from the earliest to the latest. A reminder is
+// due once the hike is closer than its lead, but not yet closer than its
+// minLead: past that its text is wrong, so a late confirmation or a restart
+// close to the hike gets only the reminders that still fit.
+var schedule = []struct {
+	kind    Kind
+	lead    time.Duration
+	minLead time.Duration
+}{
+	{Kind48h, 48 * time.Hour, 24 * time.Hour},
+	{Kind3h, 3 * time.Hour, 0},
+}
+
+type Reminder struct {
+	BookingID     int32
+	HikeTitle     string
+	HikeTitleEn   string
+	HikeStartsAt  time.Time
+	UserTgID      int64
+	UserLang      string
+	AdminName     string
+	AdminUsername string
+}
+
+type Repository interface {
+	ListDue(ctx context.Context, kind Kind, startsAfter, startsBefore time.Time) ([]Reminder, error)
+	// MarkSent returns false if the reminder was already sent.
+	MarkSent(ctx context.Context, bookingID int32, kind Kind) (bool, error)
+}
+
+type Notifier interface {
+	NotifyReminder(ctx context.Context, kind Kind, r Reminder) error
+}
+
+type Service interface {
+	// SendDue sends confirmed participants the reminders due at now.
+	SendDue(ctx context.Context, now time.Time) error
+}
+
+type service struct {
+	repo     Repository
+	notifier Notifier
+}
+
+func New(r Repository, n Notifier) Service {
+	return &service{repo: r, notifier: n}
+}
+
+// SendDue marks a reminder as sent before sending it, so a restart can't
+// send it twice. A failed send is not retried.
+func (s *service) SendDue(ctx context.Context, now time.Time) error {
+	var errs []error
+
+	for _, item := range schedule {
+		reminders, err := s.repo.ListDue(ctx, item.kind, now.Add(item.minLead), now.Add(item.lead))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, r := range reminders {
+			claimed, err := s.repo.MarkSent(ctx, r.BookingID, item.kind)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if !claimed {
+				continue
+			}
+
+			r.HikeStartsAt = r.HikeStartsAt.In(now.Location())
+			if err := s.notifier.NotifyReminder(ctx, item.kind, r); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
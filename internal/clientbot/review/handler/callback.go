@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	reviewService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/service"
+
+	reviewUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/review"
+)
+
+// Rate handles the stars under the "hike completed" notification.
+func (h *Handler) Rate(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	// review_rate:<booking_id>:<stars>
+	parts := strings.Split(strings.TrimPrefix(q.Data, "review_rate:"), ":")
+	if len(parts) != 2 {
+		return logger.WrapError(fmt.Errorf("unexpected rate callback data %q", q.Data))
+	}
+
+	bookingID, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return logger.WrapError(fmt.Errorf("parse booking id error: %v (data=%q)", err, q.Data))
+	}
+
+	rating, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return logger.WrapError(fmt.Errorf("parse rating error: %v (data=%q)", err, q.Data))
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	userID, err := h.userService.EnsureFromTelegram(ctx, q.From)
+	if err != nil {
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientError))
+		return err
+	}
+
+	err = h.reviewService.Rate(ctx, int32(bookingID), userID, rating)
+	switch {
+	case err == nil:
+	case errors.Is(err, reviewService.ErrReviewNotAllowed),
+		errors.Is(err, reviewService.ErrInvalidRating):
+		return h.replyCallback(q, i18n.T(lang, i18n.ClientReviewNotAllowed))
+	default:
+		_ = h.replyCallback(q, i18n.T(lang, i18n.ClientReviewRateFailed))
+		return err
+	}
+
+	msg := tgbot.NewMessage(q.Message.Chat.ID, reviewUI.RatedMessage(rating, lang))
+	msg.ReplyMarkup = reviewUI.CommentKeyboard(int32(bookingID), lang)
+	if _, err := h.bot.Send(msg); err != nil {
+		return logger.WrapError(err)
+	}
+
+	// The stars are not needed anymore
+	edit := tgbot.NewEditMessageReplyMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		tgbot.InlineKeyboardMarkup{InlineKeyboard: [][]tgbot.InlineKeyboardButton{}},
+	)
+	if _, err := h.bot.Request(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) AskComment(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	bookingID, err := strconv.ParseInt(strings.TrimPrefix(q.Data, "review_comment:"), 10, 32)
+	if err != nil {
+		return logger.WrapError(fmt.Errorf("parse booking id error: %v (data=%q)", err, q.Data))
+	}
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	h.setAwaitingComment(q.From.ID, int32(bookingID))
+
+	edit := tgbot.NewEditMessageTextAndMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		reviewUI.CommentPrompt(lang),
+		reviewUI.CommentPromptKeyboard(lang),
+	)
+	if _, err := h.bot.Request(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) SkipComment(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	h.takeAwaitingComment(q.From.ID)
+
+	lang, err := h.userService.Lang(ctx, q.From.ID)
+	if err != nil {
+		return err
+	}
+
+	edit := tgbot.NewEditMessageText(q.Message.Chat.ID, q.Message.MessageID, i18n.T(lang, i18n.ClientReviewSkipped))
+	if _, err := h.bot.Request(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) replyCallback(q *tgbot.CallbackQuery, text string) error {
+	cfg := tgbot.CallbackConfig{
+		CallbackQueryID: q.ID,
+		Text:            text,
+	}
+	_, err := h.bot.Request(cfg)
+
+	return err
+}
@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"sync"
+
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	reviewService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/service"
+	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
+)
+
+type Handler struct {
+	bot           *tgbot.BotAPI
+	userService   userService.Service
+	reviewService reviewService.Service
+
+	// comments holds the booking a client is typing a review for, by tg user ID
+	mu       sync.Mutex
+	comments map[int64]int32
+}
+
+func New(b *tgbot.BotAPI, uS userService.Service, rS reviewService.Service) *Handler {
+	return &Handler{
+		bot:           b,
+		userService:   uS,
+		reviewService: rS,
+		comments:      make(map[int64]int32),
+	}
+}
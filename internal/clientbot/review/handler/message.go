@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	reviewService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/service"
+
+	reviewUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/review"
+)
+
+func (h *Handler) AwaitingComment(tgUserID int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	_, ok := h.comments[tgUserID]
+	return ok
+}
+
+// DropAwaiting forgets the review the client was asked to write.
+func (h *Handler) DropAwaiting(tgUserID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.comments, tgUserID)
+}
+
+// HandleComment takes the review typed after "✍️ Написать отзыв".
+func (h *Handler) HandleComment(ctx context.Context, m *tgbot.Message) error {
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	comment := strings.TrimSpace(m.Text)
+	if comment == "" {
+		msg := tgbot.NewMessage(m.Chat.ID, reviewUI.CommentPrompt(lang))
+		msg.ReplyMarkup = reviewUI.CommentPromptKeyboard(lang)
+
+		_, err := h.bot.Send(msg)
+		return logger.WrapError(err)
+	}
+
+	bookingID, ok := h.takeAwaitingComment(m.From.ID)
+	if !ok {
+		return nil
+	}
+
+	userID, err := h.userService.EnsureFromTelegram(ctx, m.From)
+	if err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientError)))
+		return err
+	}
+
+	err = h.reviewService.SetComment(ctx, bookingID, userID, comment)
+
+	var text string
+	switch {
+	case err == nil:
+		text = i18n.T(lang, i18n.ClientReviewSaved)
+	case errors.Is(err, reviewService.ErrReviewNotRated):
+		text = i18n.T(lang, i18n.ClientReviewNotRated)
+	default:
+		text = i18n.T(lang, i18n.ClientReviewFailed)
+	}
+
+	if _, sendErr := h.bot.Send(tgbot.NewMessage(m.Chat.ID, text)); sendErr != nil && err == nil {
+		err = logger.WrapError(sendErr)
+	}
+
+	return err
+}
+
+func (h *Handler) setAwaitingComment(tgUserID int64, bookingID int32) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.comments[tgUserID] = bookingID
+}
+
+func (h *Handler) takeAwaitingComment(tgUserID int64) (int32, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bookingID, ok := h.comments[tgUserID]
+	delete(h.comments, tgUserID)
+	return bookingID, ok
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/service"
+)
+
+type repository struct {
+	queries *client.Queries
+}
+
+func New(q *client.Queries) service.Repository {
+	return &repository{queries: q}
+}
+
+func (r *repository) Rate(ctx context.Context, bookingID, userID int32, rating int) error {
+	_, err := r.queries.UpsertHikeReview(ctx, client.UpsertHikeReviewParams{
+		Rating:    int32(rating),
+		BookingID: bookingID,
+		UserID:    userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return service.ErrReviewNotAllowed
+	}
+	return logger.WrapError(err)
+}
+
+func (r *repository) SetComment(ctx context.Context, bookingID, userID int32, comment string) error {
+	updated, err := r.queries.SetHikeReviewComment(ctx, client.SetHikeReviewCommentParams{
+		Comment:   pgtype.Text{String: comment, Valid: true},
+		BookingID: bookingID,
+		UserID:    userID,
+	})
+	if err != nil {
+		return logger.WrapError(err)
+	}
+	if updated == 0 {
+		return service.ErrReviewNotRated
+	}
+	return nil
+}
+
+func (r *repository) GetHikeRating(ctx context.Context, hikeID int32, recentLimit int32) (service.Rating, error) {
+	summary, err := r.queries.GetHikeRating(ctx, hikeID)
+	if err != nil {
+		return service.Rating{}, logger.WrapError(err)
+	}
+
+	rating := service.Rating{
+		Average: summary.Average,
+		Count:   summary.ReviewsCount,
+	}
+	if rating.Count == 0 {
+		return rating, nil
+	}
+
+	rows, err := r.queries.ListRecentHikeReviews(ctx, client.ListRecentHikeReviewsParams{
+		HikeID: hikeID,
+		Limit:  recentLimit,
+	})
+	if err != nil {
+		return service.Rating{}, logger.WrapError(err)
+	}
+
+	for _, row := range rows {
+		rating.Recent = append(rating.Recent, service.Review{
+			Rating:    int(row.Rating),
+			Comment:   row.Comment.String,
+			UserName:  row.UserName,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+
+	return rating, nil
+}
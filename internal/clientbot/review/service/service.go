@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+)
+
+// recentReviewsLimit is how many written reviews the hike details show.
+const recentReviewsLimit = 3
+
+var (
+	ErrInvalidRating    = errors.New("invalid rating")
+	ErrReviewNotAllowed = errors.New("review not allowed for booking")
+	ErrReviewNotRated   = errors.New("review has no rating yet")
+)
+
+type Review struct {
+	Rating    int
+	Comment   string
+	UserName  string
+	CreatedAt time.Time
+}
+
+// Rating sums up the reviews of a hike. Recent holds the latest reviews
+// with a comment, newest first.
+type Rating struct {
+	Average float64
+	Count   int64
+	Recent  []Review
+}
+
+type Repository interface {
+	// Rate creates or updates the rating of a completed booking of the user,
+	// ErrReviewNotAllowed if there is no such booking.
+	Rate(ctx context.Context, bookingID, userID int32, rating int) error
+	// SetComment returns ErrReviewNotRated if the booking wasn't rated.
+	SetComment(ctx context.Context, bookingID, userID int32, comment string) error
+	// GetHikeRating sums up the reviews of the hike and of the other hikes
+	// of its series, so upcoming occurrences show the reviews of past ones.
+	GetHikeRating(ctx context.Context, hikeID int32, recentLimit int32) (Rating, error)
+}
+
+type Service interface {
+	Rate(ctx context.Context, bookingID, userID int32, rating int) error
+	SetComment(ctx context.Context, bookingID, userID int32, comment string) error
+	GetHikeRating(ctx context.Context, hikeID int32) (Rating, error)
+}
+
+type service struct {
+	repo Repository
+}
+
+func New(r Repository) Service {
+	return &service{repo: r}
+}
+
+func (s *service) Rate(ctx context.Context, bookingID, userID int32, rating int) error {
+	if rating < trail.MinRating || rating > trail.MaxRating {
+		return ErrInvalidRating
+	}
+	return s.repo.Rate(ctx, bookingID, userID, rating)
+}
+
+func (s *service) SetComment(ctx context.Context, bookingID, userID int32, comment string) error {
+	return s.repo.SetComment(ctx, bookingID, userID, comment)
+}
+
+func (s *service) GetHikeRating(ctx context.Context, hikeID int32) (Rating, error) {
+	return s.repo.GetHikeRating(ctx, hikeID, recentReviewsLimit)
+}
@@ -5,26 +5,44 @@ import (
 	"strings"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/config"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
 	bookingHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/handler"
 	hikeHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/handler"
+	reviewHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/handler"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/common"
+	userHandler "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/handler"
+	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
 )
 
 type router struct {
-	bot         *tgbot.BotAPI
-	cfg         config.ClientBot
-	hikeHandler *hikeHandler.Handler
-	bookHandler *bookingHandler.Handler
+	bot           *tgbot.BotAPI
+	cfg           config.ClientBot
+	hikeHandler   *hikeHandler.Handler
+	bookHandler   *bookingHandler.Handler
+	reviewHandler *reviewHandler.Handler
+	userHandler   *userHandler.Handler
+	userService   userService.Service
 }
 
-func NewRouter(b *tgbot.BotAPI, c config.ClientBot, hH *hikeHandler.Handler, bH *bookingHandler.Handler) *router {
+func NewRouter(
+	b *tgbot.BotAPI,
+	c config.ClientBot,
+	hH *hikeHandler.Handler,
+	bH *bookingHandler.Handler,
+	rH *reviewHandler.Handler,
+	uH *userHandler.Handler,
+	uS userService.Service,
+) *router {
 	return &router{
-		bot:         b,
-		cfg:         c,
-		hikeHandler: hH,
-		bookHandler: bH,
+		bot:           b,
+		cfg:           c,
+		hikeHandler:   hH,
+		bookHandler:   bH,
+		reviewHandler: rH,
+		userHandler:   uH,
+		userService:   uS,
 	}
 }
 
@@ -48,45 +66,86 @@ func (r *router) Route(ctx context.Context, u tgbot.Update) error {
 }
 
 func (r *router) routeMessage(ctx context.Context, m *tgbot.Message) error {
-	switch m.Text {
-	case "🥾 Актуальные хайки":
+	// Remember the user with the language of their Telegram app before
+	// anything else, /start and menu buttons alike
+	if _, err := r.userService.EnsureFromTelegram(ctx, m.From); err != nil {
+		return err
+	}
+
+	key, isButton := i18n.KeyOf(m.Text)
+
+	// Commands and menu buttons leave any pending input, other messages answer it
+	if isButton || m.IsCommand() || m.Contact != nil {
+		r.dropAwaiting(m.From.ID)
+	} else if ok, err := r.routeAwaiting(ctx, m); ok {
+		return err
+	}
+
+	if m.Text == "/cancel" {
+		return r.bookHandler.ListCancelableBookings(ctx, m)
+	}
+
+	switch key {
+	case i18n.BtnClientHikes:
 		return r.hikeHandler.ListActualHikes(ctx, m)
 
-	// case "🧾 Мои записи":
-	// 	// TODO позже
+	case i18n.BtnClientMyBookings:
+		return r.bookHandler.ListMyBookings(ctx, m)
+	}
 
-	case "ℹ️ Помощь":
-		return r.showHelp(m.Chat.ID)
+	if m.Contact != nil {
+		return r.userHandler.SavePhone(ctx, m)
 	}
 
-	return r.showMainMenu(m.Chat.ID)
-}
+	if m.Text == "/language" || key == i18n.BtnClientSettings {
+		return r.userHandler.ShowSettings(ctx, m)
+	}
 
-func (r *router) showMainMenu(chatID int64) error {
-	msg := tgbot.NewMessage(chatID, "Выберите раздел")
-	msg.ReplyMarkup = common.MainMenu()
+	lang, err := r.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
 
-	_, err := r.bot.Send(msg)
-	return err
+	if key == i18n.BtnClientHelp {
+		return r.showHelp(m.Chat.ID, lang)
+	}
+
+	return r.showMainMenu(m.Chat.ID, lang)
 }
 
-// TODO: убрать из роутера
-func (r *router) showHelp(chatID int64) error {
-	text := `ℹ️ <b>Как забронировать хайк</b>
+// routeAwaiting passes the message to the handler waiting for input from
+// the client, if there is one.
+func (r *router) routeAwaiting(ctx context.Context, m *tgbot.Message) (bool, error) {
+	switch {
+	case r.bookHandler.AwaitingCancelReason(m.From.ID):
+		return true, r.bookHandler.HandleCancelReason(ctx, m)
+	case r.bookHandler.AwaitingReceipt(m.From.ID):
+		return true, r.bookHandler.HandleReceipt(ctx, m)
+	case r.reviewHandler.AwaitingComment(m.From.ID):
+		return true, r.reviewHandler.HandleComment(ctx, m)
+	case r.hikeHandler.AwaitingFilterInput(m.From.ID):
+		return true, r.hikeHandler.HandleFilterInput(ctx, m)
+	}
+	return false, nil
+}
 
-1️⃣ Откройте раздел <b>🥾 Актуальные хайки</b>  
-2️⃣ Выберите понравившийся хайк  
-3️⃣ Нажмите кнопку <b>🥾 Забронировать</b>  
-4️⃣ Дождитесь ответа менеджера  
+func (r *router) dropAwaiting(tgUserID int64) {
+	r.bookHandler.DropAwaiting(tgUserID)
+	r.reviewHandler.DropAwaiting(tgUserID)
+	r.hikeHandler.DropAwaiting(tgUserID)
+}
+
+func (r *router) showMainMenu(chatID int64, lang string) error {
+	msg := tgbot.NewMessage(chatID, i18n.T(lang, i18n.ClientChooseSection))
+	msg.ReplyMarkup = common.MainMenu(lang)
 
-После бронирования:
-• Менеджер получит вашу заявку  
-• Свяжется с вами  
-• Подтвердит участие  
-`
+	_, err := r.bot.Send(msg)
+	return err
+}
 
-	msg := tgbot.NewMessage(chatID, text)
-	msg.ParseMode = "HTML"
+func (r *router) showHelp(chatID int64, lang string) error {
+	msg := tgbot.NewMessage(chatID, i18n.T(lang, i18n.ClientHelp))
+	msg.ParseMode = tgbot.ModeHTML
 
 	_, err := r.bot.Send(msg)
 	return err
@@ -98,8 +157,36 @@ func (r *router) routeClientCallback(ctx context.Context, q *tgbot.CallbackQuery
 		return r.bookHandler.BookHike(ctx, q)
 	case strings.HasPrefix(q.Data, "details_hike:"):
 		return r.hikeHandler.DetailsHike(ctx, q)
+	case strings.HasPrefix(q.Data, "catalog:"):
+		return r.hikeHandler.ShowCatalogPage(ctx, q)
+	case strings.HasPrefix(q.Data, "catalog_filter:"):
+		return r.hikeHandler.HandleFilterCallback(ctx, q)
 	case q.Data == "booking_sent":
 		return r.bookHandler.BookSent(ctx, q)
+	case strings.HasPrefix(q.Data, "my_booking_cancel:"):
+		return r.bookHandler.AskCancelBooking(ctx, q)
+	case strings.HasPrefix(q.Data, "my_booking_cancel_reason:"):
+		return r.bookHandler.SelectCancelReason(ctx, q)
+	case q.Data == "my_booking_cancel_abort":
+		return r.bookHandler.AbortCancelReason(ctx, q)
+	case strings.HasPrefix(q.Data, "my_booking_pay:"):
+		return r.bookHandler.AskPaymentReceipt(ctx, q)
+	case q.Data == "my_booking_pay_abort":
+		return r.bookHandler.AbortPaymentReceipt(ctx, q)
+	case q.Data == "my_bookings":
+		return r.bookHandler.ShowMyBookings(ctx, q)
+	case strings.HasPrefix(q.Data, "hike_change_keep:"):
+		return r.bookHandler.KeepBookingAfterChange(ctx, q)
+	case strings.HasPrefix(q.Data, "hike_change_cancel:"):
+		return r.bookHandler.CancelBookingAfterChange(ctx, q)
+	case strings.HasPrefix(q.Data, "review_rate:"):
+		return r.reviewHandler.Rate(ctx, q)
+	case strings.HasPrefix(q.Data, "review_comment:"):
+		return r.reviewHandler.AskComment(ctx, q)
+	case q.Data == "review_skip":
+		return r.reviewHandler.SkipComment(ctx, q)
+	case strings.HasPrefix(q.Data, "set_lang:"):
+		return r.userHandler.SetLanguage(ctx, q)
 	}
 
 	return nil
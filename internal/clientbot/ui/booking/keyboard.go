@@ -2,6 +2,7 @@ package booking
 
 import (
 	"fmt"
+	"strings"
 
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
@@ -30,3 +31,26 @@ func BookingMenuKeyboard() tgbot.ReplyKeyboardMarkup {
 		),
 	)
 }
+
+// BookedKeyboard swaps the booking button of the hike message for the sent
+// mark and keeps the other buttons, so the catalog carousel stays usable.
+func BookedKeyboard(kb *tgbot.InlineKeyboardMarkup, text string) tgbot.InlineKeyboardMarkup {
+	sent := tgbot.NewInlineKeyboardButtonData(text, "booking_sent")
+	if kb == nil {
+		return tgbot.NewInlineKeyboardMarkup(tgbot.NewInlineKeyboardRow(sent))
+	}
+
+	rows := make([][]tgbot.InlineKeyboardButton, 0, len(kb.InlineKeyboard))
+	for _, row := range kb.InlineKeyboard {
+		buttons := make([]tgbot.InlineKeyboardButton, 0, len(row))
+		for _, b := range row {
+			if b.CallbackData != nil && strings.HasPrefix(*b.CallbackData, "book_hike:") {
+				b = sent
+			}
+			buttons = append(buttons, b)
+		}
+		rows = append(rows, buttons)
+	}
+
+	return tgbot.NewInlineKeyboardMarkup(rows...)
+}
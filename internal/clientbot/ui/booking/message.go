@@ -6,14 +6,47 @@ import (
 	"strings"
 	"time"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
 	hikeService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
 )
 
-func ClientBookingMessage() string {
-	// TODO: В дальнейшем нужно подумаьть о сообщении по имени и с учетом выбранного языка
-	return "🤝 Вашей заявкой уже занимается менеджер AktivHike!\n\n" +
-		"С вами скоро свяжутся для уточнения деталей.\n" +
-		"Пожалуйста, ожидайте 😊"
+func WaitlistedMessage(position int64, lang string) string {
+	return i18n.T(lang, i18n.ClientBookingWaitlisted, position)
+}
+
+// AlreadyBookedMessage answers a repeated booking attempt while the
+// previous booking on the hike is still active.
+func AlreadyBookedMessage(status bookingService.BookingStatus, lang string) string {
+	switch status {
+	case bookingService.StatusWaitlisted:
+		return i18n.T(lang, i18n.ClientBookingAlreadyWaitlisted)
+	case bookingService.StatusConfirmed:
+		return i18n.T(lang, i18n.ClientBookingAlreadyConfirmed)
+	case "":
+		return i18n.T(lang, i18n.ClientBookingAlreadyBooked)
+	default:
+		return i18n.T(lang, i18n.ClientBookingAlreadyBookedStatus, StatusLabel(status, lang))
+	}
+}
+
+var statusLabels = map[bookingService.BookingStatus]i18n.Key{
+	bookingService.StatusNew:        i18n.ClientBookingStatusNew,
+	bookingService.StatusInProgress: i18n.ClientBookingStatusInProgress,
+	bookingService.StatusConfirmed:  i18n.ClientBookingStatusConfirmed,
+	bookingService.StatusCompleted:  i18n.ClientBookingStatusCompleted,
+	bookingService.StatusCanceled:   i18n.ClientBookingStatusCanceled,
+	bookingService.StatusWaitlisted: i18n.ClientBookingStatusWaitlisted,
+}
+
+// StatusLabel is the booking status as the client sees it.
+func StatusLabel(status bookingService.BookingStatus, lang string) string {
+	key, ok := statusLabels[status]
+	if !ok {
+		return string(status)
+	}
+	return i18n.T(lang, key)
 }
 
 func AdminBookingMessage(hike hikeService.Hike, bookingID int32, tgUserID int64, username, fullName, adminBot string) string {
@@ -84,6 +117,43 @@ func BookingTakenMessage(text, fullName, username string) string {
 	return text + statusLine
 }
 
+func BookingCanceledByClientMessage(text, reason string) string {
+	if reason == "" {
+		reason = "не указана"
+	}
+
+	return text + fmt.Sprintf(
+		"\n\n🔴 <b>Отменена клиентом</b>\nПричина: %s",
+		html.EscapeString(reason),
+	)
+}
+
+func ManagerBookingCanceledMessage(b bookingService.Booking) string {
+	clientName := html.EscapeString(strings.TrimSpace(b.UserName))
+	if clientName == "" {
+		clientName = "—"
+	}
+
+	reason := b.CancelReason
+	if reason == "" {
+		reason = "не указана"
+	}
+
+	return fmt.Sprintf(
+		"🔴 <b>Клиент отменил заявку #%d</b>\n\n"+
+			"📍 Хайк: %s\n"+
+			"🗓 Дата: %s\n"+
+			"👤 Клиент: <a href=\"tg://user?id=%d\">%s</a>\n"+
+			"💬 Причина: %s",
+		b.ID,
+		html.EscapeString(b.HikeTitle),
+		b.HikeStartsAt.Format("02.01.2006 15:04"),
+		b.UserTgID,
+		clientName,
+		html.EscapeString(reason),
+	)
+}
+
 func sameDate(a, b time.Time) bool {
 	return a.Year() == b.Year() &&
 		a.Month() == b.Month() &&
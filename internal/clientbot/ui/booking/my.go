@@ -0,0 +1,188 @@
+package booking
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
+	hikeUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/hike"
+)
+
+const maxPastBookings = 10
+
+// SplitByDate groups bookings into upcoming (hike not finished yet, soonest first)
+// and past (latest first, at most maxPastBookings).
+func SplitByDate(bookings []bookingService.Booking, now time.Time) (upcoming, past []bookingService.Booking) {
+	for _, b := range bookings {
+		if b.HikeEndsAt.Before(now) {
+			past = append([]bookingService.Booking{b}, past...)
+			continue
+		}
+		upcoming = append(upcoming, b)
+	}
+
+	if len(past) > maxPastBookings {
+		past = past[:maxPastBookings]
+	}
+
+	return upcoming, past
+}
+
+func MyBookingsMessage(upcoming, past []bookingService.Booking, lang string) string {
+	title := i18n.T(lang, i18n.ClientMyBookingsTitle)
+
+	if len(upcoming) == 0 && len(past) == 0 {
+		return title + "\n\n" + i18n.T(lang, i18n.ClientMyBookingsEmpty)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title + "\n")
+
+	if len(upcoming) > 0 {
+		sb.WriteString("\n" + i18n.T(lang, i18n.ClientMyBookingsUpcoming) + "\n")
+		for i, b := range upcoming {
+			writeBookingLine(&sb, i+1, b, lang)
+		}
+	}
+
+	if len(past) > 0 {
+		sb.WriteString("\n" + i18n.T(lang, i18n.ClientMyBookingsPast) + "\n")
+		for _, b := range past {
+			writeBookingLine(&sb, 0, b, lang)
+		}
+	}
+
+	return sb.String()
+}
+
+// MyBookingsKeyboard has a row per upcoming booking, numbered as in MyBookingsMessage.
+func MyBookingsKeyboard(upcoming []bookingService.Booking, lang string) tgbot.InlineKeyboardMarkup {
+	rows := make([][]tgbot.InlineKeyboardButton, 0, len(upcoming))
+
+	for i, b := range upcoming {
+		row := tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(
+				fmt.Sprintf("🔍 %d. %s", i+1, truncate(b.HikeTitleIn(lang), 24)),
+				fmt.Sprintf("details_hike:%d", b.HikeID),
+			),
+		)
+
+		if b.Status.IsActive() {
+			row = append(row, tgbot.NewInlineKeyboardButtonData(
+				i18n.T(lang, i18n.BtnClientBookingCancel),
+				fmt.Sprintf("my_booking_cancel:%d", b.ID),
+			))
+		}
+
+		rows = append(rows, row)
+
+		if b.Status == bookingService.StatusConfirmed {
+			rows = append(rows, tgbot.NewInlineKeyboardRow(
+				tgbot.NewInlineKeyboardButtonData(
+					i18n.T(lang, i18n.BtnClientBookingPay, i+1),
+					fmt.Sprintf("my_booking_pay:%d", b.ID),
+				),
+			))
+		}
+	}
+
+	return tgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// CancelableBookingsKeyboard lists active upcoming bookings for the /cancel command.
+func CancelableBookingsKeyboard(upcoming []bookingService.Booking, lang string) tgbot.InlineKeyboardMarkup {
+	var rows [][]tgbot.InlineKeyboardButton
+
+	for _, b := range upcoming {
+		if !b.Status.IsActive() {
+			continue
+		}
+
+		rows = append(rows, tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(
+				fmt.Sprintf("❌ %s · %s", truncate(b.HikeTitleIn(lang), 24), b.HikeStartsAt.Format(i18n.T(lang, i18n.ClientLayoutShortDate))),
+				fmt.Sprintf("my_booking_cancel:%d", b.ID),
+			),
+		))
+	}
+
+	return tgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+var cancelReasons = []struct {
+	code string
+	key  i18n.Key
+}{
+	{"plans", i18n.BtnClientCancelReasonPlans},
+	{"dates", i18n.BtnClientCancelReasonDates},
+	{"health", i18n.BtnClientCancelReasonHealth},
+	{"price", i18n.BtnClientCancelReasonPrice},
+}
+
+// CancelReasonText returns the reason stored for the button code. It is
+// read by managers, so it is stored in the default language.
+func CancelReasonText(code string) (string, bool) {
+	for _, r := range cancelReasons {
+		if r.code == code {
+			return i18n.T(i18n.DefaultLang, r.key), true
+		}
+	}
+	return "", false
+}
+
+func CancelReasonKeyboard(bookingID int32, lang string) tgbot.InlineKeyboardMarkup {
+	rows := make([][]tgbot.InlineKeyboardButton, 0, len(cancelReasons)+2)
+
+	for _, r := range cancelReasons {
+		rows = append(rows, tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, r.key), fmt.Sprintf("my_booking_cancel_reason:%d:%s", bookingID, r.code)),
+		))
+	}
+
+	rows = append(rows,
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientCancelReasonOther), fmt.Sprintf("my_booking_cancel_reason:%d:other", bookingID)),
+		),
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientCancelKeep), "my_bookings"),
+		),
+	)
+
+	return tgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func CustomCancelReasonKeyboard(lang string) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientCancelKeep), "my_booking_cancel_abort"),
+		),
+	)
+}
+
+func writeBookingLine(sb *strings.Builder, n int, b bookingService.Booking, lang string) {
+	if n > 0 {
+		sb.WriteString(fmt.Sprintf("%d. ", n))
+	} else {
+		sb.WriteString("• ")
+	}
+
+	sb.WriteString(fmt.Sprintf(
+		"<b>%s</b>\n    🗓 %s · %s\n",
+		html.EscapeString(b.HikeTitleIn(lang)),
+		hikeUI.FormatDateRange(b.HikeStartsAt, b.HikeEndsAt, lang),
+		StatusLabel(b.Status, lang),
+	))
+}
+
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	return string([]rune(s)[:max-1]) + "…"
+}
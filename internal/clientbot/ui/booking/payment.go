@@ -0,0 +1,63 @@
+package booking
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/money"
+	bookingService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/booking/service"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func PaymentReceiptPrompt(lang string) string {
+	return i18n.T(lang, i18n.ClientReceiptPrompt)
+}
+
+func PaymentReceiptKeyboard(lang string) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnAbort), "my_booking_pay_abort"),
+		),
+	)
+}
+
+func PaymentSubmittedMessage(p bookingService.Payment, lang string) string {
+	return i18n.T(lang, i18n.ClientReceiptSubmitted, money.Format(p.Amount), p.Currency)
+}
+
+// ManagerPaymentSubmittedMessage is the caption of the receipt sent to the manager.
+func ManagerPaymentSubmittedMessage(b bookingService.Booking, p bookingService.Payment) string {
+	clientName := html.EscapeString(strings.TrimSpace(b.UserName))
+	if clientName == "" {
+		clientName = "—"
+	}
+
+	return fmt.Sprintf(
+		"💳 <b>Чек по заявке #%d</b>\n\n"+
+			"📍 Хайк: %s\n"+
+			"🗓 Дата: %s\n"+
+			"👤 Клиент: <a href=\"tg://user?id=%d\">%s</a>\n"+
+			"💰 Сумма: %s %s из %d GEL",
+		b.ID,
+		html.EscapeString(b.HikeTitle),
+		b.HikeStartsAt.Format("02.01.2006 15:04"),
+		b.UserTgID,
+		clientName,
+		money.Format(p.Amount),
+		p.Currency,
+		b.HikePriceGel,
+	)
+}
+
+// PaymentReviewKeyboard is sent to the manager through the admin bot,
+// which handles the booking:pay_* callbacks.
+func PaymentReviewKeyboard(paymentID int32) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData("✅ Подтвердить оплату", fmt.Sprintf("booking:pay_verify:%d", paymentID)),
+			tgbot.NewInlineKeyboardButtonData("❌ Отклонить", fmt.Sprintf("booking:pay_reject:%d", paymentID)),
+		),
+	)
+}
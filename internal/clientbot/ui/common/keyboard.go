@@ -1,16 +1,22 @@
 package common
 
-import tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+import (
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
 
-func MainMenu() tgbot.ReplyKeyboardMarkup {
+func MainMenu(lang string) tgbot.ReplyKeyboardMarkup {
 	return tgbot.NewReplyKeyboard(
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("🥾 Актуальные хайки"),
-			// TODO: вернуть когда будет готов этот функционал
-			// tgbot.NewKeyboardButton("🧾 Мои записи"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnClientHikes)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnClientMyBookings)),
 		),
 		tgbot.NewKeyboardButtonRow(
-			tgbot.NewKeyboardButton("ℹ️ Помощь"),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnClientHelp)),
+			tgbot.NewKeyboardButton(i18n.T(lang, i18n.BtnClientSettings)),
+		),
+		tgbot.NewKeyboardButtonRow(
+			tgbot.NewKeyboardButtonContact(i18n.T(lang, i18n.BtnClientSharePhone)),
 		),
 	)
 }
@@ -0,0 +1,91 @@
+package hike
+
+import (
+	"fmt"
+	"time"
+
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
+)
+
+// CatalogMonthLayout formats the month in the catalog callback data:
+// catalog:page:<pos> scrolls the carousel, catalog:month:<month> limits it
+// to the month, or shows all months for CatalogAllMonths.
+const (
+	CatalogMonthLayout = "2006-01"
+	CatalogAllMonths   = "all"
+)
+
+// catalogMonthsLimit keeps the month filter within two keyboard rows.
+const catalogMonthsLimit = 5
+
+const catalogMonthsPerRow = 3
+
+// CatalogKeyboard shows the booking buttons of the hike, the carousel
+// navigation, the month filter and the button opening the other filters.
+// Buttons that would not change the carousel send catalog:noop.
+func CatalogKeyboard(p service.CatalogPage, months []time.Time, lang string) tgbot.InlineKeyboardMarkup {
+	var rows [][]tgbot.InlineKeyboardButton
+
+	if p.Total > 0 {
+		rows = append(rows, PreviewHikeActions(p.Hike, lang).InlineKeyboard...)
+	}
+
+	if p.Total > 1 {
+		rows = append(rows, tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData("◀️", catalogPageData(p.Prev())),
+			tgbot.NewInlineKeyboardButtonData(fmt.Sprintf("%d / %d", p.Pos, p.Total), "catalog:noop"),
+			tgbot.NewInlineKeyboardButtonData("▶️", catalogPageData(p.Next())),
+		))
+	}
+
+	if len(months) > 1 {
+		if len(months) > catalogMonthsLimit {
+			months = months[:catalogMonthsLimit]
+		}
+
+		// Typed dates select neither all months nor one of them
+		current, isMonth := p.Filter.Month()
+		noDates := p.Filter.StartsFrom.IsZero() && p.Filter.StartsBefore.IsZero()
+
+		filters := []tgbot.InlineKeyboardButton{
+			catalogMonthButton(i18n.T(lang, i18n.BtnClientCatalogAllMonths), time.Time{}, noDates),
+		}
+		for _, month := range months {
+			text := month.Format(i18n.T(lang, i18n.ClientLayoutMonth))
+			filters = append(filters, catalogMonthButton(text, month, isMonth && month.Equal(current)))
+		}
+
+		for len(filters) > 0 {
+			n := min(catalogMonthsPerRow, len(filters))
+			rows = append(rows, filters[:n])
+			filters = filters[n:]
+		}
+	}
+
+	rows = append(rows, tgbot.NewInlineKeyboardRow(
+		tgbot.NewInlineKeyboardButtonData(FiltersButtonText(p.Filter, lang), "catalog_filter:open"),
+	))
+
+	return tgbot.NewInlineKeyboardMarkup(rows...)
+}
+
+func catalogPageData(pos int32) string {
+	return fmt.Sprintf("catalog:page:%d", pos)
+}
+
+func catalogMonthData(month time.Time) string {
+	if month.IsZero() {
+		return "catalog:month:" + CatalogAllMonths
+	}
+	return "catalog:month:" + month.Format(CatalogMonthLayout)
+}
+
+func catalogMonthButton(text string, month time.Time, selected bool) tgbot.InlineKeyboardButton {
+	if selected {
+		return tgbot.NewInlineKeyboardButtonData("• "+text+" •", "catalog:noop")
+	}
+	return tgbot.NewInlineKeyboardButtonData(text, catalogMonthData(month))
+}
@@ -0,0 +1,104 @@
+package hike
+
+import (
+	"fmt"
+	"html"
+
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
+)
+
+// FiltersButtonText shows how many filters are set, dates count once.
+func FiltersButtonText(f service.Filter, lang string) string {
+	text := i18n.T(lang, i18n.BtnClientCatalogFilters)
+	if n := f.Active(); n > 0 {
+		text += fmt.Sprintf(" (%d)", n)
+	}
+	return text
+}
+
+func FiltersMessage(f service.Filter, total int32, lang string) string {
+	anyValue := i18n.T(lang, i18n.ClientFilterAny)
+
+	dates := anyValue
+	if !f.StartsFrom.IsZero() && !f.StartsBefore.IsZero() {
+		// StartsBefore is the day after the last one
+		dates = FormatDateRange(f.StartsFrom, f.StartsBefore.AddDate(0, 0, -1), lang)
+	}
+
+	price := anyValue
+	if f.MaxPriceGel > 0 {
+		price = i18n.T(lang, i18n.ClientFilterPriceValue, f.MaxPriceGel)
+	}
+
+	difficulty := anyValue
+	if f.Difficulty != "" {
+		difficulty = trail.DifficultyLabel(f.Difficulty, lang)
+	}
+
+	tag := anyValue
+	if f.Tag != "" {
+		tag = trail.TagLabel(f.Tag, lang)
+	}
+
+	query := anyValue
+	if f.Query != "" {
+		query = "«" + html.EscapeString(f.Query) + "»"
+	}
+
+	return i18n.T(lang, i18n.ClientFilters, dates, price, difficulty, tag, query, total)
+}
+
+// FiltersKeyboard edits the filter of the client, the callback data is
+// catalog_filter:<action>. Tapping the selected difficulty or tag clears it.
+func FiltersKeyboard(f service.Filter, lang string) tgbot.InlineKeyboardMarkup {
+	var levels []tgbot.InlineKeyboardButton
+	for _, d := range trail.Difficulties {
+		text := trail.DifficultyLabel(d, lang)
+		data := "catalog_filter:difficulty:" + string(d)
+		if d == f.Difficulty {
+			text = "• " + text + " •"
+			data = "catalog_filter:difficulty:"
+		}
+		levels = append(levels, tgbot.NewInlineKeyboardButtonData(text, data))
+	}
+
+	var tags []tgbot.InlineKeyboardButton
+	for _, t := range trail.Tags {
+		text := trail.TagLabel(t, lang)
+		data := "catalog_filter:tag:" + string(t)
+		if t == f.Tag {
+			text = "• " + text + " •"
+			data = "catalog_filter:tag:"
+		}
+		tags = append(tags, tgbot.NewInlineKeyboardButtonData(text, data))
+	}
+
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientFilterDates), "catalog_filter:dates"),
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientFilterPrice), "catalog_filter:price"),
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientFilterQuery), "catalog_filter:query"),
+		),
+		levels[:2],
+		levels[2:],
+		tags[:2],
+		tags[2:],
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientFilterReset), "catalog_filter:reset"),
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientFilterShow), "catalog_filter:show"),
+		),
+	)
+}
+
+// FilterInputKeyboard lets the client stop typing a filter value.
+func FilterInputKeyboard(lang string) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnAbort), "catalog_filter:abort"),
+		),
+	)
+}
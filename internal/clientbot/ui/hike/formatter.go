@@ -3,11 +3,14 @@ package hike
 import (
 	"fmt"
 	"time"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 )
 
-func FormatDateRange(start, end time.Time) string {
-	startDate := start.Format("02.01.2006")
-	endDate := end.Format("02.01.2006")
+func FormatDateRange(start, end time.Time, lang string) string {
+	layout := i18n.T(lang, i18n.ClientLayoutDate)
+	startDate := start.Format(layout)
+	endDate := end.Format(layout)
 
 	if startDate == endDate {
 		return startDate
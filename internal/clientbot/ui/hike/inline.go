@@ -5,29 +5,30 @@ import (
 
 	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/hike/service"
 )
 
-func PreviewHikeActions(hike service.Hike) tgbot.InlineKeyboardMarkup {
+func PreviewHikeActions(hike service.Hike, lang string) tgbot.InlineKeyboardMarkup {
 	return tgbot.NewInlineKeyboardMarkup(
 		tgbot.NewInlineKeyboardRow(
 			tgbot.NewInlineKeyboardButtonData(
-				"🥾 Забронировать",
+				i18n.T(lang, i18n.BtnClientBookHike),
 				fmt.Sprintf("book_hike:%d", hike.ID),
 			),
 			tgbot.NewInlineKeyboardButtonData(
-				"🔍 Подробнее",
+				i18n.T(lang, i18n.BtnClientHikeDetails),
 				fmt.Sprintf("details_hike:%d", hike.ID),
 			),
 		),
 	)
 }
 
-func DetailsHikeActions(hike service.Hike) tgbot.InlineKeyboardMarkup {
+func DetailsHikeActions(hike service.Hike, lang string) tgbot.InlineKeyboardMarkup {
 	return tgbot.NewInlineKeyboardMarkup(
 		tgbot.NewInlineKeyboardRow(
 			tgbot.NewInlineKeyboardButtonData(
-				"🥾 Забронировать",
+				i18n.T(lang, i18n.BtnClientBookHike),
 				fmt.Sprintf("book_hike:%d", hike.ID),
 			),
 		),
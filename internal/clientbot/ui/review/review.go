@@ -0,0 +1,81 @@
+package review
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/trail"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	reviewService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/review/service"
+)
+
+// commentPreviewLen cuts long comments in the hike details.
+const commentPreviewLen = 200
+
+func RatedMessage(rating int, lang string) string {
+	return i18n.T(lang, i18n.ClientReviewRated, Stars(rating))
+}
+
+func CommentKeyboard(bookingID int32, lang string) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientReviewWrite), fmt.Sprintf("review_comment:%d", bookingID)),
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnClientReviewLater), "review_skip"),
+		),
+	)
+}
+
+func CommentPrompt(lang string) string {
+	return i18n.T(lang, i18n.ClientReviewCommentPrompt)
+}
+
+func CommentPromptKeyboard(lang string) tgbot.InlineKeyboardMarkup {
+	return tgbot.NewInlineKeyboardMarkup(
+		tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(i18n.T(lang, i18n.BtnAbort), "review_skip"),
+		),
+	)
+}
+
+// HikeRatingBlock is appended to the hike details, empty if the hike has no reviews.
+func HikeRatingBlock(r reviewService.Rating, lang string) string {
+	if r.Count == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(i18n.T(lang, i18n.ClientReviewRating, r.Average, trail.MaxRating, r.Count))
+
+	if len(r.Recent) > 0 {
+		b.WriteString("\n\n" + i18n.T(lang, i18n.ClientReviewRecent))
+		for _, review := range r.Recent {
+			name := strings.TrimSpace(review.UserName)
+			if name == "" {
+				name = i18n.T(lang, i18n.ClientReviewAnonymous)
+			}
+
+			fmt.Fprintf(&b, "\n\n%s %s\n«%s»",
+				Stars(review.Rating),
+				html.EscapeString(name),
+				html.EscapeString(truncate(review.Comment, commentPreviewLen)),
+			)
+		}
+	}
+
+	return b.String()
+}
+
+func Stars(rating int) string {
+	return strings.Repeat("⭐", rating)
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return strings.TrimSpace(string(runes[:max])) + "…"
+}
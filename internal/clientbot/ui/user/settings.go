@@ -0,0 +1,28 @@
+package user
+
+import (
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+func SettingsMessage(lang string) string {
+	return i18n.T(lang, i18n.ClientSettings, i18n.T(lang, i18n.LangName))
+}
+
+// LanguageKeyboard lists every language of the catalog, the current one marked.
+func LanguageKeyboard(current string) tgbot.InlineKeyboardMarkup {
+	rows := make([][]tgbot.InlineKeyboardButton, 0, len(i18n.Langs()))
+
+	for _, lang := range i18n.Langs() {
+		text := i18n.T(lang, i18n.LangName)
+		if lang == current {
+			text = "✅ " + text
+		}
+
+		rows = append(rows, tgbot.NewInlineKeyboardRow(
+			tgbot.NewInlineKeyboardButtonData(text, "set_lang:"+lang),
+		))
+	}
+
+	return tgbot.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/common"
+	userUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/user"
+	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
+)
+
+// SetLanguage saves the language picked in the settings and re-renders
+// the settings and the main menu in it.
+func (h *Handler) SetLanguage(ctx context.Context, q *tgbot.CallbackQuery) error {
+	if q == nil || q.From == nil || q.Message == nil {
+		return nil
+	}
+
+	lang := strings.TrimPrefix(q.Data, "set_lang:")
+
+	err := h.userService.SetLang(ctx, userService.TelegramUser{
+		TgUserID:   q.From.ID,
+		TgUsername: q.From.UserName,
+		FullName:   strings.TrimSpace(q.From.FirstName + " " + q.From.LastName),
+		Lang:       lang,
+	})
+	if err != nil {
+		_ = h.replyCallback(q, i18n.T(i18n.Match(q.From.LanguageCode), i18n.ClientError))
+		return err
+	}
+
+	edit := tgbot.NewEditMessageTextAndMarkup(
+		q.Message.Chat.ID,
+		q.Message.MessageID,
+		userUI.SettingsMessage(lang),
+		userUI.LanguageKeyboard(lang),
+	)
+	edit.ParseMode = tgbot.ModeHTML
+	if _, err := h.bot.Request(edit); err != nil {
+		return logger.WrapError(err)
+	}
+
+	// The reply keyboard only changes with a new message
+	msg := tgbot.NewMessage(q.Message.Chat.ID, i18n.T(lang, i18n.ClientLangChanged))
+	msg.ReplyMarkup = common.MainMenu(lang)
+	if _, err := h.bot.Send(msg); err != nil {
+		return logger.WrapError(err)
+	}
+
+	return h.replyCallback(q, "")
+}
+
+func (h *Handler) replyCallback(q *tgbot.CallbackQuery, text string) error {
+	cfg := tgbot.CallbackConfig{
+		CallbackQueryID: q.ID,
+		Text:            text,
+	}
+	_, err := h.bot.Request(cfg)
+
+	return err
+}
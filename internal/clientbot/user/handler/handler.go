@@ -0,0 +1,19 @@
+package handler
+
+import (
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	userService "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
+)
+
+type Handler struct {
+	bot         *tgbot.BotAPI
+	userService userService.Service
+}
+
+func New(b *tgbot.BotAPI, uS userService.Service) *Handler {
+	return &Handler{
+		bot:         b,
+		userService: uS,
+	}
+}
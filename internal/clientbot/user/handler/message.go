@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/common"
+	userUI "github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/ui/user"
+)
+
+// ShowSettings answers the /language command and the settings button.
+func (h *Handler) ShowSettings(ctx context.Context, m *tgbot.Message) error {
+	if m == nil || m.From == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, userUI.SettingsMessage(lang))
+	msg.ParseMode = tgbot.ModeHTML
+	msg.ReplyMarkup = userUI.LanguageKeyboard(lang)
+
+	_, err = h.bot.Send(msg)
+	return logger.WrapError(err)
+}
+
+// SavePhone stores the number shared with the main menu button. Only the
+// user's own contact is accepted, not one forwarded from the address book.
+func (h *Handler) SavePhone(ctx context.Context, m *tgbot.Message) error {
+	if m == nil || m.From == nil || m.Contact == nil {
+		return nil
+	}
+
+	lang, err := h.userService.Lang(ctx, m.From.ID)
+	if err != nil {
+		return err
+	}
+
+	text := i18n.T(lang, i18n.ClientPhoneSaved)
+	if m.Contact.UserID != m.From.ID {
+		text = i18n.T(lang, i18n.ClientPhoneNotOwn, i18n.T(lang, i18n.BtnClientSharePhone))
+	} else if err := h.userService.SetPhone(ctx, m.From.ID, m.Contact.PhoneNumber); err != nil {
+		_, _ = h.bot.Send(tgbot.NewMessage(m.Chat.ID, i18n.T(lang, i18n.ClientError)))
+		return err
+	}
+
+	msg := tgbot.NewMessage(m.Chat.ID, text)
+	msg.ReplyMarkup = common.MainMenu(lang)
+
+	_, err = h.bot.Send(msg)
+	return logger.WrapError(err)
+}
@@ -2,11 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/clientbot/user/service"
 	sqlc "github.com/boris-guzeev/aktiv-hike-bot/internal/db/sqlc/client"
 	"github.com/boris-guzeev/aktiv-hike-bot/internal/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -32,11 +34,24 @@ func (r *repository) GetByID(ctx context.Context, id int32) (service.TelegramUse
 	}, nil
 }
 
+func (r *repository) GetLang(ctx context.Context, tgUserID int64) (string, error) {
+	lang, err := r.queries.GetTelegramUserLang(ctx, tgUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", service.ErrUserNotFound
+		}
+		return "", logger.WrapError(err)
+	}
+
+	return lang, nil
+}
+
 func (r *repository) UpsertTelegramUser(ctx context.Context, tgUser service.TelegramUser) (int32, error) {
 	id, err := r.queries.UpsertTelegramUser(ctx, sqlc.UpsertTelegramUserParams{
 		TgUserID:   tgUser.TgUserID,
 		TgUsername: toPgText(tgUser.TgUsername),
 		FullName:   toPgText(tgUser.FullName),
+		Lang:       tgUser.Lang,
 	})
 	if err != nil {
 		return 0, logger.WrapError(err)
@@ -45,6 +60,24 @@ func (r *repository) UpsertTelegramUser(ctx context.Context, tgUser service.Tele
 	return id, nil
 }
 
+func (r *repository) SetLang(ctx context.Context, tgUser service.TelegramUser) error {
+	err := r.queries.SetTelegramUserLang(ctx, sqlc.SetTelegramUserLangParams{
+		TgUserID:   tgUser.TgUserID,
+		TgUsername: toPgText(tgUser.TgUsername),
+		FullName:   toPgText(tgUser.FullName),
+		Lang:       tgUser.Lang,
+	})
+	return logger.WrapError(err)
+}
+
+func (r *repository) SetPhone(ctx context.Context, tgUserID int64, phone string) error {
+	err := r.queries.SetTelegramUserPhone(ctx, sqlc.SetTelegramUserPhoneParams{
+		TgUserID: tgUserID,
+		Phone:    toPgText(phone),
+	})
+	return logger.WrapError(err)
+}
+
 // TODO: вынести отдельно в utils
 func toPgText(s string) pgtype.Text {
 	s = strings.TrimSpace(s)
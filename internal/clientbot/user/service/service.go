@@ -1,6 +1,13 @@
 package service
 
-import "context"
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/boris-guzeev/aktiv-hike-bot/internal/app/i18n"
+	tgbot "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
 
 type TelegramUser struct {
 	ID         int32
@@ -10,14 +17,33 @@ type TelegramUser struct {
 	Lang       string
 }
 
+var (
+	ErrUserNotFound    = errors.New("telegram user not found")
+	ErrUnsupportedLang = errors.New("unsupported language")
+)
+
 type Repository interface {
 	GetByID(ctx context.Context, id int32) (TelegramUser, error)
+	GetLang(ctx context.Context, tgUserID int64) (string, error)
 	UpsertTelegramUser(ctx context.Context, tgUser TelegramUser) (int32, error)
+	SetLang(ctx context.Context, tgUser TelegramUser) error
+	SetPhone(ctx context.Context, tgUserID int64, phone string) error
 }
 
 type Service interface {
 	GetByID(ctx context.Context, id int32) (TelegramUser, error)
-	EnsureTelegramUser(ctx context.Context, tgUser TelegramUser) (int32, error)
+	// Lang returns the language of the Telegram user, i18n.DefaultLang
+	// for users the bot doesn't know yet.
+	Lang(ctx context.Context, tgUserID int64) (string, error)
+	// SetLang saves the language the user has chosen in tgUser.Lang.
+	SetLang(ctx context.Context, tgUser TelegramUser) error
+	// EnsureFromTelegram creates the sender of an update or refreshes their
+	// name and returns their ID. The language of the Telegram app is only
+	// stored for a new user, a chosen language is kept.
+	EnsureFromTelegram(ctx context.Context, from *tgbot.User) (int32, error)
+	// SetPhone saves the phone number the user has shared, managers see it
+	// in the hike roster.
+	SetPhone(ctx context.Context, tgUserID int64, phone string) error
 }
 
 type service struct {
@@ -32,6 +58,35 @@ func (s *service) GetByID(ctx context.Context, id int32) (TelegramUser, error) {
 	return s.repo.GetByID(ctx, id)
 }
 
-func (s *service) EnsureTelegramUser(ctx context.Context, tgUser TelegramUser) (int32, error) {
-	return s.repo.UpsertTelegramUser(ctx, tgUser)
+func (s *service) Lang(ctx context.Context, tgUserID int64) (string, error) {
+	lang, err := s.repo.GetLang(ctx, tgUserID)
+	if errors.Is(err, ErrUserNotFound) || (err == nil && !i18n.Supported(lang)) {
+		return i18n.DefaultLang, nil
+	}
+	return lang, err
+}
+
+func (s *service) SetLang(ctx context.Context, tgUser TelegramUser) error {
+	if !i18n.Supported(tgUser.Lang) {
+		return ErrUnsupportedLang
+	}
+	return s.repo.SetLang(ctx, tgUser)
+}
+
+func (s *service) EnsureFromTelegram(ctx context.Context, from *tgbot.User) (int32, error) {
+	return s.repo.UpsertTelegramUser(ctx, TelegramUser{
+		TgUserID:   from.ID,
+		TgUsername: from.UserName,
+		FullName:   strings.TrimSpace(from.FirstName + " " + from.LastName),
+		Lang:       i18n.Match(from.LanguageCode),
+	})
+}
+
+func (s *service) SetPhone(ctx context.Context, tgUserID int64, phone string) error {
+	// Telegram sends the number without the plus on some clients
+	phone = strings.TrimSpace(phone)
+	if phone != "" && !strings.HasPrefix(phone, "+") {
+		phone = "+" + phone
+	}
+	return s.repo.SetPhone(ctx, tgUserID, phone)
 }
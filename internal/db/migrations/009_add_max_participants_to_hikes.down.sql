@@ -0,0 +1,2 @@
+ALTER TABLE hikes
+    DROP COLUMN max_participants;
\ No newline at end of file
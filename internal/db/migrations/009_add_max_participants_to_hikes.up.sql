@@ -0,0 +1,2 @@
+ALTER TABLE hikes
+    ADD COLUMN max_participants INTEGER;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE admin_fsm_sessions;
\ No newline at end of file
@@ -0,0 +1,10 @@
+CREATE TABLE admin_fsm_sessions (
+    tg_user_id BIGINT NOT NULL,
+    slot       TEXT NOT NULL,
+    state      TEXT NOT NULL,
+    data       JSONB NOT NULL DEFAULT '{}'::jsonb,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (tg_user_id, slot)
+);
+
+CREATE INDEX idx_admin_fsm_sessions_updated_at ON admin_fsm_sessions (updated_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE bookings
+    DROP COLUMN admin_message_id,
+    DROP COLUMN cancel_reason;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE bookings
+    ADD COLUMN cancel_reason TEXT,
+    ADD COLUMN admin_message_id BIGINT;
\ No newline at end of file
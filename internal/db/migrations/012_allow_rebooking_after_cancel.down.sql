@@ -0,0 +1,3 @@
+DROP INDEX uniq_bookings_active_hike_user;
+
+ALTER TABLE bookings ADD CONSTRAINT bookings_hike_id_user_id_key UNIQUE (hike_id, user_id);
\ No newline at end of file
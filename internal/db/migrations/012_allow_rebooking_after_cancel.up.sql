@@ -0,0 +1,7 @@
+ALTER TABLE bookings DROP CONSTRAINT bookings_hike_id_user_id_key;
+
+-- Only one active booking per client and hike: canceled and completed
+-- bookings stay as history and don't block booking the hike again.
+CREATE UNIQUE INDEX uniq_bookings_active_hike_user
+    ON bookings (hike_id, user_id)
+    WHERE status IN ('new', 'waitlisted', 'in_progress', 'confirmed');
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE payments
+    DROP COLUMN reviewed_at,
+    DROP COLUMN reviewed_by_admin_id;
\ No newline at end of file
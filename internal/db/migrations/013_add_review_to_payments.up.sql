@@ -0,0 +1,3 @@
+ALTER TABLE payments
+    ADD COLUMN reviewed_by_admin_id INT REFERENCES telegram_users(id) ON DELETE SET NULL,
+    ADD COLUMN reviewed_at TIMESTAMPTZ;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE booking_reminders;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE booking_reminders (
+    booking_id INT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    kind       TEXT NOT NULL, -- 48h|3h
+    sent_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (booking_id, kind)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE hike_reviews;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE hike_reviews (
+    id         SERIAL PRIMARY KEY,
+    hike_id    INT NOT NULL REFERENCES hikes(id) ON DELETE CASCADE,
+    booking_id INT NOT NULL UNIQUE REFERENCES bookings(id) ON DELETE CASCADE,
+    user_id    INT NOT NULL REFERENCES telegram_users(id) ON DELETE CASCADE,
+    rating     INT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    comment    TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_hike_reviews_hike_id ON hike_reviews (hike_id, created_at DESC);
\ No newline at end of file
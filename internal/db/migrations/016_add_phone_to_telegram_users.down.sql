@@ -0,0 +1 @@
+ALTER TABLE telegram_users DROP COLUMN phone;
\ No newline at end of file
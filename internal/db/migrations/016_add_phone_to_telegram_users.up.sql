@@ -0,0 +1 @@
+ALTER TABLE telegram_users ADD COLUMN phone TEXT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+DROP INDEX idx_hikes_publish_at;
+
+ALTER TABLE hikes
+    DROP COLUMN publish_at,
+    DROP COLUMN unpublish_at;
\ No newline at end of file
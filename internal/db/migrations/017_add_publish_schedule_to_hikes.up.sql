@@ -0,0 +1,5 @@
+ALTER TABLE hikes
+    ADD COLUMN publish_at TIMESTAMPTZ,
+    ADD COLUMN unpublish_at TIMESTAMPTZ;
+
+CREATE INDEX idx_hikes_publish_at ON hikes (publish_at) WHERE publish_at IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE bookings DROP COLUMN escalated_at;
\ No newline at end of file
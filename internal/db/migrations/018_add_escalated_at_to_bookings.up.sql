@@ -0,0 +1 @@
+ALTER TABLE bookings ADD COLUMN escalated_at TIMESTAMPTZ;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE hikes DROP COLUMN series_id;
+
+DROP TABLE hike_series;
\ No newline at end of file
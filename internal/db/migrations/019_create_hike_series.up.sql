@@ -0,0 +1,8 @@
+CREATE TABLE hike_series (
+    id         SERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE hikes ADD COLUMN series_id INT REFERENCES hike_series(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_hikes_series_id ON hikes (series_id) WHERE series_id IS NOT NULL;
\ No newline at end of file
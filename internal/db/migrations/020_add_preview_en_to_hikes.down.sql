@@ -0,0 +1 @@
+ALTER TABLE hikes DROP COLUMN preview_en;
\ No newline at end of file
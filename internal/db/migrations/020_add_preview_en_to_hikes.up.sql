@@ -0,0 +1 @@
+ALTER TABLE hikes ADD COLUMN preview_en TEXT;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX idx_hikes_search;
\ No newline at end of file
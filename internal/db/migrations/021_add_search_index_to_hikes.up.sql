@@ -0,0 +1,4 @@
+CREATE INDEX idx_hikes_search ON hikes USING GIN ((
+    to_tsvector('russian', title_ru || ' ' || preview_ru || ' ' || description_ru)
+    || to_tsvector('english', COALESCE(title_en, '') || ' ' || COALESCE(preview_en, '') || ' ' || COALESCE(description_en, ''))
+));
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX idx_hikes_tags;
+
+ALTER TABLE hikes DROP COLUMN difficulty, DROP COLUMN tags;
\ No newline at end of file
@@ -0,0 +1,14 @@
+ALTER TABLE hikes
+    ADD COLUMN difficulty TEXT NOT NULL DEFAULT 'easy'
+        CHECK (difficulty IN ('easy', 'moderate', 'hard', 'expert')),
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Existing hikes get the level the creation scenario would suggest.
+UPDATE hikes SET difficulty = CASE
+    WHEN COALESCE(distance_km, 0) + COALESCE(elevation_gain_m, 0) / 100.0 >= 26 THEN 'expert'
+    WHEN COALESCE(distance_km, 0) + COALESCE(elevation_gain_m, 0) / 100.0 >= 18 THEN 'hard'
+    WHEN COALESCE(distance_km, 0) + COALESCE(elevation_gain_m, 0) / 100.0 >= 10 THEN 'moderate'
+    ELSE 'easy'
+END;
+
+CREATE INDEX idx_hikes_tags ON hikes USING GIN (tags);
\ No newline at end of file
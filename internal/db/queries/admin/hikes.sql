@@ -15,26 +15,52 @@ INSERT INTO hikes (
     price_gel,
     distance_km,
     elevation_gain_m,
-    is_published
-) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+    is_published,
+    max_participants,
+    publish_at,
+    unpublish_at,
+    series_id,
+    preview_en,
+    difficulty,
+    tags
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)
 RETURNING id;
 
+-- name: CreateHikeSeries :one
+INSERT INTO hike_series DEFAULT VALUES
+RETURNING id;
+
+-- name: SetHikeSeries :exec
+UPDATE hikes SET series_id = $2 WHERE id = $1;
+
 -- name: UpdateHike :one
 UPDATE hikes SET
     title_ru       = $2,
-    title_en       = $3,
-    description_ru = $4,
-    description_en = $5,
-    starts_at      = $6,
-    ends_at        = $7,
-    photo_file_id  = $8,
-    is_published   = $9,
-    distance_km    = $10,
-    elevation_gain_m = $11,
-    updated_at       = $12
-WHERE id = $1
+    preview_ru     = $3,
+    title_en       = $4,
+    description_ru = $5,
+    description_en = $6,
+    starts_at      = $7,
+    ends_at        = $8,
+    photo_file_id  = $9,
+    price_gel      = $10,
+    distance_km    = $11,
+    elevation_gain_m = $12,
+    max_participants = $13,
+    publish_at       = $14,
+    unpublish_at     = $15,
+    preview_en       = $16,
+    difficulty       = $17,
+    tags             = $18,
+    updated_at       = now()
+WHERE id = $1 AND updated_at = $19
 RETURNING *;
 
+-- name: DeleteHikeReminders :exec
+DELETE FROM booking_reminders r
+USING bookings b
+WHERE b.id = r.booking_id AND b.hike_id = $1;
+
 -- name: UpdateImagePath :exec
 UPDATE hikes SET image_path = $2 WHERE id = $1;
 
@@ -54,24 +80,59 @@ SELECT
     starts_at, 
     ends_at, 
     is_published, 
+    publish_at,
     created_at 
 FROM 
     hikes 
-ORDER BY is_published DESC, created_at DESC
-LIMIT $1 OFFSET $2;
+WHERE CASE sqlc.arg(filter)::text
+    WHEN 'published' THEN is_published AND ends_at >= sqlc.arg(now)::timestamptz
+    WHEN 'draft' THEN NOT is_published AND ends_at >= sqlc.arg(now)::timestamptz
+    WHEN 'past' THEN ends_at < sqlc.arg(now)::timestamptz
+    ELSE true
+END
+ORDER BY is_published DESC, created_at DESC, id DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountHikes :one
+SELECT COUNT(*)
+FROM hikes
+WHERE CASE sqlc.arg(filter)::text
+    WHEN 'published' THEN is_published AND ends_at >= sqlc.arg(now)::timestamptz
+    WHEN 'draft' THEN NOT is_published AND ends_at >= sqlc.arg(now)::timestamptz
+    WHEN 'past' THEN ends_at < sqlc.arg(now)::timestamptz
+    ELSE true
+END;
 
 -- name: ListActualHikes :many
 SELECT id, title_ru, starts_at, ends_at, is_published
 FROM hikes
 WHERE is_published = true AND ends_at >= now()
+    AND (unpublish_at IS NULL OR unpublish_at > now())
 ORDER BY starts_at ASC
 LIMIT $1 OFFSET $2;
 
 -- name: SetPublished :exec
 UPDATE hikes
-SET is_published = $1, updated_at = now()
+SET is_published = $1, publish_at = NULL, updated_at = now()
 WHERE id = $2;
 
+-- name: PublishDueHikes :execrows
+UPDATE hikes
+SET is_published = true, publish_at = NULL, updated_at = now()
+WHERE is_published = false
+    AND publish_at <= sqlc.arg(now)::timestamptz
+    AND ends_at >= sqlc.arg(now)::timestamptz;
+
+-- name: ListHikeActiveBookings :many
+SELECT
+    b.id,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang
+FROM bookings b
+JOIN telegram_users u ON u.id = b.user_id
+WHERE b.hike_id = $1 AND b.status IN ('new', 'waitlisted', 'in_progress', 'confirmed')
+ORDER BY b.id;
+
 -- =========================================
 -- TELEGRAM USERS
 -- =========================================
@@ -82,8 +143,7 @@ VALUES ($1, $2, $3, $4)
 ON CONFLICT (tg_user_id)
 DO UPDATE SET
     tg_username = EXCLUDED.tg_username,
-    full_name   = EXCLUDED.full_name,
-    lang        = EXCLUDED.lang
+    full_name   = EXCLUDED.full_name
 RETURNING id;
 
 -- =========================================
@@ -94,10 +154,61 @@ RETURNING id;
 SELECT id, hike_id, user_id, status, taken_by_admin_id
 FROM bookings WHERE id = $1;
 
+-- name: GetBookingDetails :one
+SELECT
+    b.id,
+    b.hike_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    h.ends_at AS hike_ends_at,
+    b.user_id,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang,
+    b.status,
+    b.taken_by_admin_id,
+    COALESCE(a.full_name, '') AS admin_name,
+    COALESCE(a.tg_username, '') AS admin_username,
+    b.admin_message_id,
+    COALESCE(b.cancel_reason, '') AS cancel_reason,
+    b.created_at
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN telegram_users a ON a.id = b.taken_by_admin_id
+WHERE b.id = $1;
+
+-- name: CancelHikeBookings :many
+UPDATE bookings
+SET status = 'canceled', cancel_reason = $2, updated_at = now()
+WHERE hike_id = $1 AND status IN ('new', 'waitlisted', 'in_progress', 'confirmed')
+RETURNING id;
+
+-- name: CompleteFinishedBookings :many
+UPDATE bookings b
+SET status = 'completed', updated_at = now()
+FROM hikes h
+WHERE h.id = b.hike_id AND b.status = 'confirmed' AND h.ends_at < $1
+RETURNING b.id;
+
+-- name: ExpireUntakenBookings :many
+UPDATE bookings
+SET status = 'canceled', cancel_reason = $1, updated_at = now()
+WHERE status = 'new' AND updated_at < $2
+RETURNING id, hike_id;
+
+-- name: MarkUntakenBookingsEscalated :many
+UPDATE bookings
+SET escalated_at = now()
+WHERE status = 'new' AND escalated_at IS NULL AND updated_at < $1
+RETURNING id;
+
 -- name: UpdateBookingStatus :one
 UPDATE bookings
-SET status = sqlc.arg(new_status)
-WHERE id = $1
+SET status = sqlc.arg(new_status), updated_at = now()
+WHERE id = sqlc.arg(id) AND status = sqlc.arg(expected_status)
 RETURNING *;
 
 -- name: ListAdminBookings :many
@@ -110,12 +221,186 @@ SELECT
     u.tg_user_id AS user_tg_id,
     b.status,
     b.taken_at,
-    b.created_at
+    b.created_at,
+    h.price_gel AS hike_price_gel,
+    COALESCE(pay.paid, 0)::float8 AS paid_amount,
+    COALESCE(pay.last_status, '')::text AS payment_status
 FROM bookings b
 JOIN hikes h ON h.id = b.hike_id
 JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN LATERAL (
+    SELECT
+        SUM(p.amount) FILTER (WHERE p.status = 'verified') AS paid,
+        (array_agg(p.status ORDER BY p.id DESC))[1] AS last_status
+    FROM payments p
+    WHERE p.booking_id = b.id
+) pay ON true
 WHERE
     b.taken_by_admin_id = $1
     AND b.status IN ('in_progress', 'confirmed')
 ORDER BY
-    b.created_at DESC;
\ No newline at end of file
+    b.created_at DESC;
+
+-- name: ListHikeRoster :many
+SELECT
+    b.id,
+    b.status,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username,
+    u.tg_user_id AS user_tg_id,
+    COALESCE(u.phone, '') AS user_phone,
+    h.price_gel AS hike_price_gel,
+    COALESCE(pay.paid, 0)::float8 AS paid_amount,
+    COALESCE(pay.last_status, '')::text AS payment_status
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN LATERAL (
+    SELECT
+        SUM(p.amount) FILTER (WHERE p.status = 'verified') AS paid,
+        (array_agg(p.status ORDER BY p.id DESC))[1] AS last_status
+    FROM payments p
+    WHERE p.booking_id = b.id
+) pay ON true
+WHERE b.hike_id = $1
+ORDER BY
+    CASE b.status
+        WHEN 'confirmed' THEN 1
+        WHEN 'completed' THEN 2
+        WHEN 'in_progress' THEN 3
+        WHEN 'new' THEN 4
+        WHEN 'waitlisted' THEN 5
+        ELSE 6
+    END,
+    b.id;
+
+-- =========================================
+-- FSM SESSIONS
+-- =========================================
+
+-- name: GetFSMSession :one
+SELECT state, data, updated_at
+FROM admin_fsm_sessions
+WHERE tg_user_id = $1 AND slot = $2;
+
+-- name: SaveFSMSession :exec
+INSERT INTO admin_fsm_sessions (tg_user_id, slot, state, data, updated_at)
+VALUES ($1, $2, $3, $4, now())
+ON CONFLICT (tg_user_id, slot)
+DO UPDATE SET
+    state      = EXCLUDED.state,
+    data       = EXCLUDED.data,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteFSMSession :exec
+DELETE FROM admin_fsm_sessions
+WHERE tg_user_id = $1 AND slot = $2;
+
+-- name: DeleteExpiredFSMSessions :execrows
+DELETE FROM admin_fsm_sessions
+WHERE updated_at < $1;
+
+-- =========================================
+-- BOOKING STATS
+-- =========================================
+
+-- name: CountBookingsByStatus :many
+SELECT status, COUNT(*) AS count
+FROM bookings
+WHERE created_at >= $1
+GROUP BY status;
+
+-- name: GetBookingTakeTimeStats :one
+SELECT
+    COUNT(*) AS taken,
+    COALESCE(AVG(EXTRACT(EPOCH FROM taken_at - created_at)), 0)::float8 AS avg_take_seconds
+FROM bookings
+WHERE created_at >= $1 AND taken_at IS NOT NULL;
+
+-- name: ListBookingStatsByHike :many
+SELECT
+    h.id AS hike_id,
+    h.title_ru AS hike_title,
+    h.starts_at AS hike_starts_at,
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE b.status IN ('confirmed', 'completed')) AS confirmed,
+    COUNT(*) FILTER (WHERE b.status = 'completed') AS completed,
+    COUNT(*) FILTER (WHERE b.status = 'canceled') AS canceled
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+WHERE b.created_at >= $1
+GROUP BY h.id, h.title_ru, h.starts_at
+ORDER BY total DESC, h.starts_at DESC;
+
+-- name: ListBookingStatsByAdmin :many
+SELECT
+    u.id AS admin_id,
+    COALESCE(u.full_name, '') AS admin_name,
+    COALESCE(u.tg_username, '') AS admin_username,
+    COUNT(*) AS taken,
+    COUNT(*) FILTER (WHERE b.status IN ('confirmed', 'completed')) AS confirmed,
+    COUNT(*) FILTER (WHERE b.status = 'completed') AS completed,
+    COUNT(*) FILTER (WHERE b.status = 'canceled') AS canceled,
+    COALESCE(AVG(EXTRACT(EPOCH FROM b.taken_at - b.created_at)), 0)::float8 AS avg_take_seconds
+FROM bookings b
+JOIN telegram_users u ON u.id = b.taken_by_admin_id
+WHERE b.created_at >= $1
+GROUP BY u.id, u.full_name, u.tg_username
+ORDER BY taken DESC;
+
+-- name: CountHikeBookingsByStatus :many
+SELECT status, COUNT(*) AS count
+FROM bookings
+WHERE hike_id = $1
+GROUP BY status;
+
+-- name: GetHikeRevenue :one
+SELECT COALESCE(SUM(p.amount), 0)::float8 AS revenue
+FROM payments p
+JOIN bookings b ON b.id = p.booking_id
+WHERE b.hike_id = $1 AND p.status = 'verified';
+
+-- =========================================
+-- PAYMENTS
+-- =========================================
+
+-- name: GetPaymentDetails :one
+SELECT
+    p.id,
+    p.booking_id,
+    p.amount::float8 AS amount,
+    p.currency,
+    p.status,
+    b.taken_by_admin_id,
+    h.title_ru AS hike_title,
+    h.price_gel AS hike_price_gel,
+    u.tg_user_id AS user_tg_id
+FROM payments p
+JOIN bookings b ON b.id = p.booking_id
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+WHERE p.id = $1;
+
+-- name: ReviewPayment :one
+UPDATE payments
+SET status = $2, reviewed_by_admin_id = $3, reviewed_at = now()
+WHERE id = $1 AND status = 'submitted'
+RETURNING id;
+
+-- =========================================
+-- REVIEWS
+-- =========================================
+
+-- name: ListHikeReviews :many
+SELECT
+    r.id,
+    r.booking_id,
+    r.rating,
+    r.comment,
+    r.created_at,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username
+FROM hike_reviews r
+JOIN telegram_users u ON u.id = r.user_id
+WHERE r.hike_id = $1
+ORDER BY r.created_at DESC;
\ No newline at end of file
@@ -3,37 +3,172 @@ SELECT
     id, 
     title_ru, 
     preview_ru,
+    title_en,
+    preview_en,
     starts_at, 
     ends_at, 
     image_path,
     price_gel,
     distance_km,
-    elevation_gain_m
+    elevation_gain_m,
+    difficulty,
+    tags
 FROM hikes
 WHERE is_published = true AND ends_at >= now()
-ORDER BY starts_at ASC
-LIMIT $1 OFFSET $2;
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+    AND (sqlc.narg(starts_from)::timestamptz IS NULL OR starts_at >= sqlc.narg(starts_from))
+    AND (sqlc.narg(starts_before)::timestamptz IS NULL OR starts_at < sqlc.narg(starts_before))
+    AND (sqlc.narg(max_price)::int IS NULL OR price_gel <= sqlc.narg(max_price))
+    AND (sqlc.narg(difficulty)::text IS NULL OR difficulty = sqlc.narg(difficulty))
+    AND (sqlc.narg(tag)::text IS NULL OR tags @> ARRAY[sqlc.narg(tag)::text])
+    AND (sqlc.narg(query)::text IS NULL OR (
+        to_tsvector('russian', title_ru || ' ' || preview_ru || ' ' || description_ru)
+        || to_tsvector('english', COALESCE(title_en, '') || ' ' || COALESCE(preview_en, '') || ' ' || COALESCE(description_en, ''))
+    ) @@ (websearch_to_tsquery('russian', sqlc.narg(query)) || websearch_to_tsquery('english', sqlc.narg(query))))
+ORDER BY starts_at ASC, id ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountActualHikes :one
+SELECT COUNT(*)
+FROM hikes
+WHERE is_published = true AND ends_at >= now()
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+    AND (sqlc.narg(starts_from)::timestamptz IS NULL OR starts_at >= sqlc.narg(starts_from))
+    AND (sqlc.narg(starts_before)::timestamptz IS NULL OR starts_at < sqlc.narg(starts_before))
+    AND (sqlc.narg(max_price)::int IS NULL OR price_gel <= sqlc.narg(max_price))
+    AND (sqlc.narg(difficulty)::text IS NULL OR difficulty = sqlc.narg(difficulty))
+    AND (sqlc.narg(tag)::text IS NULL OR tags @> ARRAY[sqlc.narg(tag)::text])
+    AND (sqlc.narg(query)::text IS NULL OR (
+        to_tsvector('russian', title_ru || ' ' || preview_ru || ' ' || description_ru)
+        || to_tsvector('english', COALESCE(title_en, '') || ' ' || COALESCE(preview_en, '') || ' ' || COALESCE(description_en, ''))
+    ) @@ (websearch_to_tsquery('russian', sqlc.narg(query)) || websearch_to_tsquery('english', sqlc.narg(query))));
+
+-- name: ListActualHikeStarts :many
+SELECT starts_at
+FROM hikes
+WHERE is_published = true AND ends_at >= now()
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+    AND (sqlc.narg(max_price)::int IS NULL OR price_gel <= sqlc.narg(max_price))
+    AND (sqlc.narg(difficulty)::text IS NULL OR difficulty = sqlc.narg(difficulty))
+    AND (sqlc.narg(tag)::text IS NULL OR tags @> ARRAY[sqlc.narg(tag)::text])
+    AND (sqlc.narg(query)::text IS NULL OR (
+        to_tsvector('russian', title_ru || ' ' || preview_ru || ' ' || description_ru)
+        || to_tsvector('english', COALESCE(title_en, '') || ' ' || COALESCE(preview_en, '') || ' ' || COALESCE(description_en, ''))
+    ) @@ (websearch_to_tsquery('russian', sqlc.narg(query)) || websearch_to_tsquery('english', sqlc.narg(query))))
+ORDER BY starts_at ASC, id ASC;
 
 -- name: GetHike :one
 SELECT 
     id, 
     title_ru, 
     description_ru,
+    title_en,
+    description_en,
     starts_at, 
     ends_at
 FROM hikes
-WHERE id = $1 AND is_published = true; 
+WHERE id = $1 AND is_published = true
+    AND (unpublish_at IS NULL OR unpublish_at > now()); 
+
+-- name: GetHikeCapacityForUpdate :one
+SELECT max_participants FROM hikes
+WHERE id = $1 AND is_published
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+FOR UPDATE;
+
+-- name: CountActiveBookings :one
+SELECT COUNT(*) FROM bookings
+WHERE hike_id = $1 AND status IN ('new', 'in_progress', 'confirmed');
+
+-- name: GetWaitlistPosition :one
+SELECT COUNT(*) FROM bookings
+WHERE hike_id = $1 AND status = 'waitlisted' AND id <= $2;
 
 -- name: CreateBooking :one
 INSERT INTO bookings (hike_id, user_id, status)
 VALUES ($1, $2, $3)
-ON CONFLICT (hike_id, user_id) DO NOTHING
+ON CONFLICT (hike_id, user_id) WHERE status IN ('new', 'waitlisted', 'in_progress', 'confirmed') DO NOTHING
 RETURNING id;
 
+-- name: GetLatestUserHikeBooking :one
+SELECT id, status
+FROM bookings
+WHERE hike_id = $1 AND user_id = $2
+ORDER BY id DESC
+LIMIT 1;
+
 -- name: GetBookingByID :one
 SELECT id, hike_id, user_id, status, taken_by_admin_id, taken_at
 FROM bookings WHERE id = $1;
 
+-- name: GetBookingDetails :one
+SELECT
+    b.id,
+    b.hike_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    h.ends_at AS hike_ends_at,
+    h.price_gel AS hike_price_gel,
+    b.user_id,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang,
+    b.status,
+    b.taken_by_admin_id,
+    COALESCE(a.full_name, '') AS admin_name,
+    COALESCE(a.tg_username, '') AS admin_username,
+    a.tg_user_id AS admin_tg_id,
+    b.admin_message_id,
+    b.cancel_reason,
+    b.created_at
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN telegram_users a ON a.id = b.taken_by_admin_id
+WHERE b.id = $1;
+
+-- name: SetBookingAdminMessage :exec
+UPDATE bookings SET admin_message_id = $2 WHERE id = $1;
+
+-- name: CreatePayment :one
+INSERT INTO payments (booking_id, amount, currency, proof_file_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id;
+
+-- name: ListUserBookings :many
+SELECT
+    b.id,
+    b.hike_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    h.ends_at AS hike_ends_at,
+    b.status,
+    b.created_at
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+WHERE b.user_id = $1
+ORDER BY h.starts_at ASC;
+
+-- name: CancelBookingByClient :one
+UPDATE bookings
+SET status = 'canceled', cancel_reason = $3, updated_at = now()
+WHERE id = $1 AND user_id = $2 AND status IN ('new', 'waitlisted', 'in_progress', 'confirmed')
+RETURNING hike_id;
+
+-- name: PromoteNextWaitlistedBooking :one
+UPDATE bookings
+SET status = 'new', updated_at = now()
+WHERE id = (
+    SELECT w.id FROM bookings w
+    WHERE w.hike_id = $1 AND w.status = 'waitlisted'
+    ORDER BY w.id
+    LIMIT 1
+)
+RETURNING id;
+
 -- name: TakeBookingInProgress :one
 UPDATE bookings
 SET
@@ -55,17 +190,94 @@ SELECT id, tg_user_id, tg_username, full_name
 FROM telegram_users
 WHERE id = $1;
 
+-- name: GetTelegramUserLang :one
+SELECT lang FROM telegram_users WHERE tg_user_id = $1;
+
 -- name: UpsertTelegramUser :one
 INSERT INTO telegram_users (tg_user_id, tg_username, full_name, lang)
 VALUES ($1, $2, $3, $4)
 ON CONFLICT (tg_user_id)
 DO UPDATE SET
     tg_username = EXCLUDED.tg_username,
-    full_name   = EXCLUDED.full_name,
-    lang        = EXCLUDED.lang
+    full_name   = EXCLUDED.full_name
 RETURNING id;
 
+-- name: SetTelegramUserLang :exec
+INSERT INTO telegram_users (tg_user_id, tg_username, full_name, lang)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (tg_user_id)
+DO UPDATE SET lang = EXCLUDED.lang;
+
+-- name: SetTelegramUserPhone :exec
+UPDATE telegram_users SET phone = $2 WHERE tg_user_id = $1;
+
 -- name: CreateAdminIfNotExists :exec
 INSERT INTO admins (id)
 VALUES ($1)
-ON CONFLICT DO NOTHING;
\ No newline at end of file
+ON CONFLICT DO NOTHING;
+
+-- name: ListDueReminders :many
+SELECT
+    b.id AS booking_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang,
+    COALESCE(a.full_name, '') AS admin_name,
+    COALESCE(a.tg_username, '') AS admin_username
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN telegram_users a ON a.id = b.taken_by_admin_id
+WHERE
+    b.status = 'confirmed'
+    AND h.starts_at > sqlc.arg(starts_after)
+    AND h.starts_at <= sqlc.arg(starts_before)
+    AND NOT EXISTS (
+        SELECT 1 FROM booking_reminders r
+        WHERE r.booking_id = b.id AND r.kind = sqlc.arg(kind)
+    )
+ORDER BY h.starts_at;
+
+-- name: MarkReminderSent :execrows
+INSERT INTO booking_reminders (booking_id, kind)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: UpsertHikeReview :one
+INSERT INTO hike_reviews (hike_id, booking_id, user_id, rating)
+SELECT b.hike_id, b.id, b.user_id, sqlc.arg(rating)::int
+FROM bookings b
+WHERE b.id = sqlc.arg(booking_id) AND b.user_id = sqlc.arg(user_id) AND b.status = 'completed'
+ON CONFLICT (booking_id) DO UPDATE
+SET rating = EXCLUDED.rating, updated_at = now()
+RETURNING id;
+
+-- name: SetHikeReviewComment :execrows
+UPDATE hike_reviews
+SET comment = $1, updated_at = now()
+WHERE booking_id = $2 AND user_id = $3;
+
+-- name: GetHikeRating :one
+SELECT
+    COALESCE(AVG(r.rating), 0)::float8 AS average,
+    COUNT(*) AS reviews_count
+FROM hike_reviews r
+JOIN hikes h ON h.id = r.hike_id
+JOIN hikes t ON t.id = sqlc.arg(hike_id)::int
+WHERE h.id = t.id OR h.series_id = t.series_id;
+
+-- name: ListRecentHikeReviews :many
+SELECT
+    r.rating,
+    r.comment,
+    r.created_at,
+    COALESCE(u.full_name, '') AS user_name
+FROM hike_reviews r
+JOIN telegram_users u ON u.id = r.user_id
+JOIN hikes h ON h.id = r.hike_id
+JOIN hikes t ON t.id = sqlc.arg(hike_id)::int
+WHERE (h.id = t.id OR h.series_id = t.series_id) AND r.comment IS NOT NULL
+ORDER BY r.created_at DESC
+LIMIT sqlc.arg('limit');
\ No newline at end of file
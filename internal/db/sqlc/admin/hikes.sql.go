@@ -12,6 +12,157 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelHikeBookings = `-- name: CancelHikeBookings :many
+UPDATE bookings
+SET status = 'canceled', cancel_reason = $2, updated_at = now()
+WHERE hike_id = $1 AND status IN ('new', 'waitlisted', 'in_progress', 'confirmed')
+RETURNING id
+`
+
+type CancelHikeBookingsParams struct {
+	HikeID       int32       `db:"hike_id" json:"hike_id"`
+	CancelReason pgtype.Text `db:"cancel_reason" json:"cancel_reason"`
+}
+
+func (q *Queries) CancelHikeBookings(ctx context.Context, arg CancelHikeBookingsParams) ([]int32, error) {
+	rows, err := q.db.Query(ctx, cancelHikeBookings, arg.HikeID, arg.CancelReason)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const completeFinishedBookings = `-- name: CompleteFinishedBookings :many
+UPDATE bookings b
+SET status = 'completed', updated_at = now()
+FROM hikes h
+WHERE h.id = b.hike_id AND b.status = 'confirmed' AND h.ends_at < $1
+RETURNING b.id
+`
+
+func (q *Queries) CompleteFinishedBookings(ctx context.Context, endsAt time.Time) ([]int32, error) {
+	rows, err := q.db.Query(ctx, completeFinishedBookings, endsAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countBookingsByStatus = `-- name: CountBookingsByStatus :many
+
+SELECT status, COUNT(*) AS count
+FROM bookings
+WHERE created_at >= $1
+GROUP BY status
+`
+
+type CountBookingsByStatusRow struct {
+	Status string `db:"status" json:"status"`
+	Count  int64  `db:"count" json:"count"`
+}
+
+// =========================================
+// BOOKING STATS
+// =========================================
+func (q *Queries) CountBookingsByStatus(ctx context.Context, createdAt time.Time) ([]CountBookingsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countBookingsByStatus, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountBookingsByStatusRow
+	for rows.Next() {
+		var i CountBookingsByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countHikeBookingsByStatus = `-- name: CountHikeBookingsByStatus :many
+SELECT status, COUNT(*) AS count
+FROM bookings
+WHERE hike_id = $1
+GROUP BY status
+`
+
+type CountHikeBookingsByStatusRow struct {
+	Status string `db:"status" json:"status"`
+	Count  int64  `db:"count" json:"count"`
+}
+
+func (q *Queries) CountHikeBookingsByStatus(ctx context.Context, hikeID int32) ([]CountHikeBookingsByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countHikeBookingsByStatus, hikeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountHikeBookingsByStatusRow
+	for rows.Next() {
+		var i CountHikeBookingsByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countHikes = `-- name: CountHikes :one
+SELECT COUNT(*)
+FROM hikes
+WHERE CASE $1::text
+    WHEN 'published' THEN is_published AND ends_at >= $2::timestamptz
+    WHEN 'draft' THEN NOT is_published AND ends_at >= $2::timestamptz
+    WHEN 'past' THEN ends_at < $2::timestamptz
+    ELSE true
+END
+`
+
+type CountHikesParams struct {
+	Filter string    `db:"filter" json:"filter"`
+	Now    time.Time `db:"now" json:"now"`
+}
+
+func (q *Queries) CountHikes(ctx context.Context, arg CountHikesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countHikes, arg.Filter, arg.Now)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createHike = `-- name: CreateHike :one
 
 INSERT INTO hikes (
@@ -26,24 +177,38 @@ INSERT INTO hikes (
     price_gel,
     distance_km,
     elevation_gain_m,
-    is_published
-) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+    is_published,
+    max_participants,
+    publish_at,
+    unpublish_at,
+    series_id,
+    preview_en,
+    difficulty,
+    tags
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)
 RETURNING id
 `
 
 type CreateHikeParams struct {
-	TitleRu        string         `db:"title_ru" json:"title_ru"`
-	PreviewRu      string         `db:"preview_ru" json:"preview_ru"`
-	TitleEn        pgtype.Text    `db:"title_en" json:"title_en"`
-	DescriptionRu  string         `db:"description_ru" json:"description_ru"`
-	DescriptionEn  pgtype.Text    `db:"description_en" json:"description_en"`
-	StartsAt       time.Time      `db:"starts_at" json:"starts_at"`
-	EndsAt         time.Time      `db:"ends_at" json:"ends_at"`
-	PhotoFileID    pgtype.Text    `db:"photo_file_id" json:"photo_file_id"`
-	PriceGel       int32          `db:"price_gel" json:"price_gel"`
-	DistanceKm     pgtype.Numeric `db:"distance_km" json:"distance_km"`
-	ElevationGainM pgtype.Int4    `db:"elevation_gain_m" json:"elevation_gain_m"`
-	IsPublished    bool           `db:"is_published" json:"is_published"`
+	TitleRu         string             `db:"title_ru" json:"title_ru"`
+	PreviewRu       string             `db:"preview_ru" json:"preview_ru"`
+	TitleEn         pgtype.Text        `db:"title_en" json:"title_en"`
+	DescriptionRu   string             `db:"description_ru" json:"description_ru"`
+	DescriptionEn   pgtype.Text        `db:"description_en" json:"description_en"`
+	StartsAt        time.Time          `db:"starts_at" json:"starts_at"`
+	EndsAt          time.Time          `db:"ends_at" json:"ends_at"`
+	PhotoFileID     pgtype.Text        `db:"photo_file_id" json:"photo_file_id"`
+	PriceGel        int32              `db:"price_gel" json:"price_gel"`
+	DistanceKm      pgtype.Numeric     `db:"distance_km" json:"distance_km"`
+	ElevationGainM  pgtype.Int4        `db:"elevation_gain_m" json:"elevation_gain_m"`
+	IsPublished     bool               `db:"is_published" json:"is_published"`
+	MaxParticipants pgtype.Int4        `db:"max_participants" json:"max_participants"`
+	PublishAt       pgtype.Timestamptz `db:"publish_at" json:"publish_at"`
+	UnpublishAt     pgtype.Timestamptz `db:"unpublish_at" json:"unpublish_at"`
+	SeriesID        pgtype.Int4        `db:"series_id" json:"series_id"`
+	PreviewEn       pgtype.Text        `db:"preview_en" json:"preview_en"`
+	Difficulty      string             `db:"difficulty" json:"difficulty"`
+	Tags            []string           `db:"tags" json:"tags"`
 }
 
 // =========================================
@@ -63,12 +228,59 @@ func (q *Queries) CreateHike(ctx context.Context, arg CreateHikeParams) (int32,
 		arg.DistanceKm,
 		arg.ElevationGainM,
 		arg.IsPublished,
+		arg.MaxParticipants,
+		arg.PublishAt,
+		arg.UnpublishAt,
+		arg.SeriesID,
+		arg.PreviewEn,
+		arg.Difficulty,
+		arg.Tags,
 	)
 	var id int32
 	err := row.Scan(&id)
 	return id, err
 }
 
+const createHikeSeries = `-- name: CreateHikeSeries :one
+INSERT INTO hike_series DEFAULT VALUES
+RETURNING id
+`
+
+func (q *Queries) CreateHikeSeries(ctx context.Context) (int32, error) {
+	row := q.db.QueryRow(ctx, createHikeSeries)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const deleteExpiredFSMSessions = `-- name: DeleteExpiredFSMSessions :execrows
+DELETE FROM admin_fsm_sessions
+WHERE updated_at < $1
+`
+
+func (q *Queries) DeleteExpiredFSMSessions(ctx context.Context, updatedAt time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredFSMSessions, updatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFSMSession = `-- name: DeleteFSMSession :exec
+DELETE FROM admin_fsm_sessions
+WHERE tg_user_id = $1 AND slot = $2
+`
+
+type DeleteFSMSessionParams struct {
+	TgUserID int64  `db:"tg_user_id" json:"tg_user_id"`
+	Slot     string `db:"slot" json:"slot"`
+}
+
+func (q *Queries) DeleteFSMSession(ctx context.Context, arg DeleteFSMSessionParams) error {
+	_, err := q.db.Exec(ctx, deleteFSMSession, arg.TgUserID, arg.Slot)
+	return err
+}
+
 const deleteHike = `-- name: DeleteHike :exec
 DELETE FROM hikes WHERE id = $1
 `
@@ -78,6 +290,54 @@ func (q *Queries) DeleteHike(ctx context.Context, id int32) error {
 	return err
 }
 
+const deleteHikeReminders = `-- name: DeleteHikeReminders :exec
+DELETE FROM booking_reminders r
+USING bookings b
+WHERE b.id = r.booking_id AND b.hike_id = $1
+`
+
+func (q *Queries) DeleteHikeReminders(ctx context.Context, hikeID int32) error {
+	_, err := q.db.Exec(ctx, deleteHikeReminders, hikeID)
+	return err
+}
+
+const expireUntakenBookings = `-- name: ExpireUntakenBookings :many
+UPDATE bookings
+SET status = 'canceled', cancel_reason = $1, updated_at = now()
+WHERE status = 'new' AND updated_at < $2
+RETURNING id, hike_id
+`
+
+type ExpireUntakenBookingsParams struct {
+	CancelReason pgtype.Text `db:"cancel_reason" json:"cancel_reason"`
+	UpdatedAt    time.Time   `db:"updated_at" json:"updated_at"`
+}
+
+type ExpireUntakenBookingsRow struct {
+	ID     int32 `db:"id" json:"id"`
+	HikeID int32 `db:"hike_id" json:"hike_id"`
+}
+
+func (q *Queries) ExpireUntakenBookings(ctx context.Context, arg ExpireUntakenBookingsParams) ([]ExpireUntakenBookingsRow, error) {
+	rows, err := q.db.Query(ctx, expireUntakenBookings, arg.CancelReason, arg.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExpireUntakenBookingsRow
+	for rows.Next() {
+		var i ExpireUntakenBookingsRow
+		if err := rows.Scan(&i.ID, &i.HikeID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getBookingByID = `-- name: GetBookingByID :one
 
 SELECT id, hike_id, user_id, status, taken_by_admin_id
@@ -108,8 +368,130 @@ func (q *Queries) GetBookingByID(ctx context.Context, id int32) (GetBookingByIDR
 	return i, err
 }
 
+const getBookingDetails = `-- name: GetBookingDetails :one
+SELECT
+    b.id,
+    b.hike_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    h.ends_at AS hike_ends_at,
+    b.user_id,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang,
+    b.status,
+    b.taken_by_admin_id,
+    COALESCE(a.full_name, '') AS admin_name,
+    COALESCE(a.tg_username, '') AS admin_username,
+    b.admin_message_id,
+    COALESCE(b.cancel_reason, '') AS cancel_reason,
+    b.created_at
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN telegram_users a ON a.id = b.taken_by_admin_id
+WHERE b.id = $1
+`
+
+type GetBookingDetailsRow struct {
+	ID             int32       `db:"id" json:"id"`
+	HikeID         int32       `db:"hike_id" json:"hike_id"`
+	HikeTitle      string      `db:"hike_title" json:"hike_title"`
+	HikeTitleEn    string      `db:"hike_title_en" json:"hike_title_en"`
+	HikeStartsAt   time.Time   `db:"hike_starts_at" json:"hike_starts_at"`
+	HikeEndsAt     time.Time   `db:"hike_ends_at" json:"hike_ends_at"`
+	UserID         int32       `db:"user_id" json:"user_id"`
+	UserName       string      `db:"user_name" json:"user_name"`
+	UserUsername   string      `db:"user_username" json:"user_username"`
+	UserTgID       int64       `db:"user_tg_id" json:"user_tg_id"`
+	UserLang       string      `db:"user_lang" json:"user_lang"`
+	Status         string      `db:"status" json:"status"`
+	TakenByAdminID pgtype.Int4 `db:"taken_by_admin_id" json:"taken_by_admin_id"`
+	AdminName      string      `db:"admin_name" json:"admin_name"`
+	AdminUsername  string      `db:"admin_username" json:"admin_username"`
+	AdminMessageID pgtype.Int8 `db:"admin_message_id" json:"admin_message_id"`
+	CancelReason   string      `db:"cancel_reason" json:"cancel_reason"`
+	CreatedAt      time.Time   `db:"created_at" json:"created_at"`
+}
+
+func (q *Queries) GetBookingDetails(ctx context.Context, id int32) (GetBookingDetailsRow, error) {
+	row := q.db.QueryRow(ctx, getBookingDetails, id)
+	var i GetBookingDetailsRow
+	err := row.Scan(
+		&i.ID,
+		&i.HikeID,
+		&i.HikeTitle,
+		&i.HikeTitleEn,
+		&i.HikeStartsAt,
+		&i.HikeEndsAt,
+		&i.UserID,
+		&i.UserName,
+		&i.UserUsername,
+		&i.UserTgID,
+		&i.UserLang,
+		&i.Status,
+		&i.TakenByAdminID,
+		&i.AdminName,
+		&i.AdminUsername,
+		&i.AdminMessageID,
+		&i.CancelReason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getBookingTakeTimeStats = `-- name: GetBookingTakeTimeStats :one
+SELECT
+    COUNT(*) AS taken,
+    COALESCE(AVG(EXTRACT(EPOCH FROM taken_at - created_at)), 0)::float8 AS avg_take_seconds
+FROM bookings
+WHERE created_at >= $1 AND taken_at IS NOT NULL
+`
+
+type GetBookingTakeTimeStatsRow struct {
+	Taken          int64   `db:"taken" json:"taken"`
+	AvgTakeSeconds float64 `db:"avg_take_seconds" json:"avg_take_seconds"`
+}
+
+func (q *Queries) GetBookingTakeTimeStats(ctx context.Context, createdAt time.Time) (GetBookingTakeTimeStatsRow, error) {
+	row := q.db.QueryRow(ctx, getBookingTakeTimeStats, createdAt)
+	var i GetBookingTakeTimeStatsRow
+	err := row.Scan(&i.Taken, &i.AvgTakeSeconds)
+	return i, err
+}
+
+const getFSMSession = `-- name: GetFSMSession :one
+
+SELECT state, data, updated_at
+FROM admin_fsm_sessions
+WHERE tg_user_id = $1 AND slot = $2
+`
+
+type GetFSMSessionParams struct {
+	TgUserID int64  `db:"tg_user_id" json:"tg_user_id"`
+	Slot     string `db:"slot" json:"slot"`
+}
+
+type GetFSMSessionRow struct {
+	State     string    `db:"state" json:"state"`
+	Data      []byte    `db:"data" json:"data"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// =========================================
+// FSM SESSIONS
+// =========================================
+func (q *Queries) GetFSMSession(ctx context.Context, arg GetFSMSessionParams) (GetFSMSessionRow, error) {
+	row := q.db.QueryRow(ctx, getFSMSession, arg.TgUserID, arg.Slot)
+	var i GetFSMSessionRow
+	err := row.Scan(&i.State, &i.Data, &i.UpdatedAt)
+	return i, err
+}
+
 const getHikeByID = `-- name: GetHikeByID :one
-SELECT id, title_ru, title_en, description_ru, description_en, starts_at, ends_at, photo_file_id, is_published, created_at, updated_at, image_path, price_gel, elevation_gain_m, distance_km, preview_ru FROM hikes WHERE id = $1
+SELECT id, title_ru, title_en, description_ru, description_en, starts_at, ends_at, photo_file_id, is_published, created_at, updated_at, image_path, price_gel, elevation_gain_m, distance_km, preview_ru, max_participants, publish_at, unpublish_at, series_id, preview_en, difficulty, tags FROM hikes WHERE id = $1
 `
 
 func (q *Queries) GetHikeByID(ctx context.Context, id int32) (Hike, error) {
@@ -132,6 +514,78 @@ func (q *Queries) GetHikeByID(ctx context.Context, id int32) (Hike, error) {
 		&i.ElevationGainM,
 		&i.DistanceKm,
 		&i.PreviewRu,
+		&i.MaxParticipants,
+		&i.PublishAt,
+		&i.UnpublishAt,
+		&i.SeriesID,
+		&i.PreviewEn,
+		&i.Difficulty,
+		&i.Tags,
+	)
+	return i, err
+}
+
+const getHikeRevenue = `-- name: GetHikeRevenue :one
+SELECT COALESCE(SUM(p.amount), 0)::float8 AS revenue
+FROM payments p
+JOIN bookings b ON b.id = p.booking_id
+WHERE b.hike_id = $1 AND p.status = 'verified'
+`
+
+func (q *Queries) GetHikeRevenue(ctx context.Context, hikeID int32) (float64, error) {
+	row := q.db.QueryRow(ctx, getHikeRevenue, hikeID)
+	var revenue float64
+	err := row.Scan(&revenue)
+	return revenue, err
+}
+
+const getPaymentDetails = `-- name: GetPaymentDetails :one
+
+SELECT
+    p.id,
+    p.booking_id,
+    p.amount::float8 AS amount,
+    p.currency,
+    p.status,
+    b.taken_by_admin_id,
+    h.title_ru AS hike_title,
+    h.price_gel AS hike_price_gel,
+    u.tg_user_id AS user_tg_id
+FROM payments p
+JOIN bookings b ON b.id = p.booking_id
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+WHERE p.id = $1
+`
+
+type GetPaymentDetailsRow struct {
+	ID             int32       `db:"id" json:"id"`
+	BookingID      int32       `db:"booking_id" json:"booking_id"`
+	Amount         float64     `db:"amount" json:"amount"`
+	Currency       string      `db:"currency" json:"currency"`
+	Status         string      `db:"status" json:"status"`
+	TakenByAdminID pgtype.Int4 `db:"taken_by_admin_id" json:"taken_by_admin_id"`
+	HikeTitle      string      `db:"hike_title" json:"hike_title"`
+	HikePriceGel   int32       `db:"hike_price_gel" json:"hike_price_gel"`
+	UserTgID       int64       `db:"user_tg_id" json:"user_tg_id"`
+}
+
+// =========================================
+// PAYMENTS
+// =========================================
+func (q *Queries) GetPaymentDetails(ctx context.Context, id int32) (GetPaymentDetailsRow, error) {
+	row := q.db.QueryRow(ctx, getPaymentDetails, id)
+	var i GetPaymentDetailsRow
+	err := row.Scan(
+		&i.ID,
+		&i.BookingID,
+		&i.Amount,
+		&i.Currency,
+		&i.Status,
+		&i.TakenByAdminID,
+		&i.HikeTitle,
+		&i.HikePriceGel,
+		&i.UserTgID,
 	)
 	return i, err
 }
@@ -140,6 +594,7 @@ const listActualHikes = `-- name: ListActualHikes :many
 SELECT id, title_ru, starts_at, ends_at, is_published
 FROM hikes
 WHERE is_published = true AND ends_at >= now()
+    AND (unpublish_at IS NULL OR unpublish_at > now())
 ORDER BY starts_at ASC
 LIMIT $1 OFFSET $2
 `
@@ -193,10 +648,20 @@ SELECT
     u.tg_user_id AS user_tg_id,
     b.status,
     b.taken_at,
-    b.created_at
+    b.created_at,
+    h.price_gel AS hike_price_gel,
+    COALESCE(pay.paid, 0)::float8 AS paid_amount,
+    COALESCE(pay.last_status, '')::text AS payment_status
 FROM bookings b
 JOIN hikes h ON h.id = b.hike_id
 JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN LATERAL (
+    SELECT
+        SUM(p.amount) FILTER (WHERE p.status = 'verified') AS paid,
+        (array_agg(p.status ORDER BY p.id DESC))[1] AS last_status
+    FROM payments p
+    WHERE p.booking_id = b.id
+) pay ON true
 WHERE
     b.taken_by_admin_id = $1
     AND b.status IN ('in_progress', 'confirmed')
@@ -205,15 +670,18 @@ ORDER BY
 `
 
 type ListAdminBookingsRow struct {
-	ID        int32              `db:"id" json:"id"`
-	HikeID    int32              `db:"hike_id" json:"hike_id"`
-	HikeTitle string             `db:"hike_title" json:"hike_title"`
-	UserID    int32              `db:"user_id" json:"user_id"`
-	UserName  string             `db:"user_name" json:"user_name"`
-	UserTgID  int64              `db:"user_tg_id" json:"user_tg_id"`
-	Status    string             `db:"status" json:"status"`
-	TakenAt   pgtype.Timestamptz `db:"taken_at" json:"taken_at"`
-	CreatedAt time.Time          `db:"created_at" json:"created_at"`
+	ID            int32              `db:"id" json:"id"`
+	HikeID        int32              `db:"hike_id" json:"hike_id"`
+	HikeTitle     string             `db:"hike_title" json:"hike_title"`
+	UserID        int32              `db:"user_id" json:"user_id"`
+	UserName      string             `db:"user_name" json:"user_name"`
+	UserTgID      int64              `db:"user_tg_id" json:"user_tg_id"`
+	Status        string             `db:"status" json:"status"`
+	TakenAt       pgtype.Timestamptz `db:"taken_at" json:"taken_at"`
+	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
+	HikePriceGel  int32              `db:"hike_price_gel" json:"hike_price_gel"`
+	PaidAmount    float64            `db:"paid_amount" json:"paid_amount"`
+	PaymentStatus string             `db:"payment_status" json:"payment_status"`
 }
 
 func (q *Queries) ListAdminBookings(ctx context.Context, takenByAdminID pgtype.Int4) ([]ListAdminBookingsRow, error) {
@@ -235,6 +703,290 @@ func (q *Queries) ListAdminBookings(ctx context.Context, takenByAdminID pgtype.I
 			&i.Status,
 			&i.TakenAt,
 			&i.CreatedAt,
+			&i.HikePriceGel,
+			&i.PaidAmount,
+			&i.PaymentStatus,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookingStatsByAdmin = `-- name: ListBookingStatsByAdmin :many
+SELECT
+    u.id AS admin_id,
+    COALESCE(u.full_name, '') AS admin_name,
+    COALESCE(u.tg_username, '') AS admin_username,
+    COUNT(*) AS taken,
+    COUNT(*) FILTER (WHERE b.status IN ('confirmed', 'completed')) AS confirmed,
+    COUNT(*) FILTER (WHERE b.status = 'completed') AS completed,
+    COUNT(*) FILTER (WHERE b.status = 'canceled') AS canceled,
+    COALESCE(AVG(EXTRACT(EPOCH FROM b.taken_at - b.created_at)), 0)::float8 AS avg_take_seconds
+FROM bookings b
+JOIN telegram_users u ON u.id = b.taken_by_admin_id
+WHERE b.created_at >= $1
+GROUP BY u.id, u.full_name, u.tg_username
+ORDER BY taken DESC
+`
+
+type ListBookingStatsByAdminRow struct {
+	AdminID        int32   `db:"admin_id" json:"admin_id"`
+	AdminName      string  `db:"admin_name" json:"admin_name"`
+	AdminUsername  string  `db:"admin_username" json:"admin_username"`
+	Taken          int64   `db:"taken" json:"taken"`
+	Confirmed      int64   `db:"confirmed" json:"confirmed"`
+	Completed      int64   `db:"completed" json:"completed"`
+	Canceled       int64   `db:"canceled" json:"canceled"`
+	AvgTakeSeconds float64 `db:"avg_take_seconds" json:"avg_take_seconds"`
+}
+
+func (q *Queries) ListBookingStatsByAdmin(ctx context.Context, createdAt time.Time) ([]ListBookingStatsByAdminRow, error) {
+	rows, err := q.db.Query(ctx, listBookingStatsByAdmin, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBookingStatsByAdminRow
+	for rows.Next() {
+		var i ListBookingStatsByAdminRow
+		if err := rows.Scan(
+			&i.AdminID,
+			&i.AdminName,
+			&i.AdminUsername,
+			&i.Taken,
+			&i.Confirmed,
+			&i.Completed,
+			&i.Canceled,
+			&i.AvgTakeSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookingStatsByHike = `-- name: ListBookingStatsByHike :many
+SELECT
+    h.id AS hike_id,
+    h.title_ru AS hike_title,
+    h.starts_at AS hike_starts_at,
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE b.status IN ('confirmed', 'completed')) AS confirmed,
+    COUNT(*) FILTER (WHERE b.status = 'completed') AS completed,
+    COUNT(*) FILTER (WHERE b.status = 'canceled') AS canceled
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+WHERE b.created_at >= $1
+GROUP BY h.id, h.title_ru, h.starts_at
+ORDER BY total DESC, h.starts_at DESC
+`
+
+type ListBookingStatsByHikeRow struct {
+	HikeID       int32     `db:"hike_id" json:"hike_id"`
+	HikeTitle    string    `db:"hike_title" json:"hike_title"`
+	HikeStartsAt time.Time `db:"hike_starts_at" json:"hike_starts_at"`
+	Total        int64     `db:"total" json:"total"`
+	Confirmed    int64     `db:"confirmed" json:"confirmed"`
+	Completed    int64     `db:"completed" json:"completed"`
+	Canceled     int64     `db:"canceled" json:"canceled"`
+}
+
+func (q *Queries) ListBookingStatsByHike(ctx context.Context, createdAt time.Time) ([]ListBookingStatsByHikeRow, error) {
+	rows, err := q.db.Query(ctx, listBookingStatsByHike, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListBookingStatsByHikeRow
+	for rows.Next() {
+		var i ListBookingStatsByHikeRow
+		if err := rows.Scan(
+			&i.HikeID,
+			&i.HikeTitle,
+			&i.HikeStartsAt,
+			&i.Total,
+			&i.Confirmed,
+			&i.Completed,
+			&i.Canceled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHikeActiveBookings = `-- name: ListHikeActiveBookings :many
+SELECT
+    b.id,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang
+FROM bookings b
+JOIN telegram_users u ON u.id = b.user_id
+WHERE b.hike_id = $1 AND b.status IN ('new', 'waitlisted', 'in_progress', 'confirmed')
+ORDER BY b.id
+`
+
+type ListHikeActiveBookingsRow struct {
+	ID       int32  `db:"id" json:"id"`
+	UserTgID int64  `db:"user_tg_id" json:"user_tg_id"`
+	UserLang string `db:"user_lang" json:"user_lang"`
+}
+
+func (q *Queries) ListHikeActiveBookings(ctx context.Context, hikeID int32) ([]ListHikeActiveBookingsRow, error) {
+	rows, err := q.db.Query(ctx, listHikeActiveBookings, hikeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListHikeActiveBookingsRow
+	for rows.Next() {
+		var i ListHikeActiveBookingsRow
+		if err := rows.Scan(&i.ID, &i.UserTgID, &i.UserLang); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHikeReviews = `-- name: ListHikeReviews :many
+
+SELECT
+    r.id,
+    r.booking_id,
+    r.rating,
+    r.comment,
+    r.created_at,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username
+FROM hike_reviews r
+JOIN telegram_users u ON u.id = r.user_id
+WHERE r.hike_id = $1
+ORDER BY r.created_at DESC
+`
+
+type ListHikeReviewsRow struct {
+	ID           int32       `db:"id" json:"id"`
+	BookingID    int32       `db:"booking_id" json:"booking_id"`
+	Rating       int32       `db:"rating" json:"rating"`
+	Comment      pgtype.Text `db:"comment" json:"comment"`
+	CreatedAt    time.Time   `db:"created_at" json:"created_at"`
+	UserName     string      `db:"user_name" json:"user_name"`
+	UserUsername string      `db:"user_username" json:"user_username"`
+}
+
+// =========================================
+// REVIEWS
+// =========================================
+func (q *Queries) ListHikeReviews(ctx context.Context, hikeID int32) ([]ListHikeReviewsRow, error) {
+	rows, err := q.db.Query(ctx, listHikeReviews, hikeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListHikeReviewsRow
+	for rows.Next() {
+		var i ListHikeReviewsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.BookingID,
+			&i.Rating,
+			&i.Comment,
+			&i.CreatedAt,
+			&i.UserName,
+			&i.UserUsername,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHikeRoster = `-- name: ListHikeRoster :many
+SELECT
+    b.id,
+    b.status,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username,
+    u.tg_user_id AS user_tg_id,
+    COALESCE(u.phone, '') AS user_phone,
+    h.price_gel AS hike_price_gel,
+    COALESCE(pay.paid, 0)::float8 AS paid_amount,
+    COALESCE(pay.last_status, '')::text AS payment_status
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN LATERAL (
+    SELECT
+        SUM(p.amount) FILTER (WHERE p.status = 'verified') AS paid,
+        (array_agg(p.status ORDER BY p.id DESC))[1] AS last_status
+    FROM payments p
+    WHERE p.booking_id = b.id
+) pay ON true
+WHERE b.hike_id = $1
+ORDER BY
+    CASE b.status
+        WHEN 'confirmed' THEN 1
+        WHEN 'completed' THEN 2
+        WHEN 'in_progress' THEN 3
+        WHEN 'new' THEN 4
+        WHEN 'waitlisted' THEN 5
+        ELSE 6
+    END,
+    b.id
+`
+
+type ListHikeRosterRow struct {
+	ID            int32   `db:"id" json:"id"`
+	Status        string  `db:"status" json:"status"`
+	UserName      string  `db:"user_name" json:"user_name"`
+	UserUsername  string  `db:"user_username" json:"user_username"`
+	UserTgID      int64   `db:"user_tg_id" json:"user_tg_id"`
+	UserPhone     string  `db:"user_phone" json:"user_phone"`
+	HikePriceGel  int32   `db:"hike_price_gel" json:"hike_price_gel"`
+	PaidAmount    float64 `db:"paid_amount" json:"paid_amount"`
+	PaymentStatus string  `db:"payment_status" json:"payment_status"`
+}
+
+func (q *Queries) ListHikeRoster(ctx context.Context, hikeID int32) ([]ListHikeRosterRow, error) {
+	rows, err := q.db.Query(ctx, listHikeRoster, hikeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListHikeRosterRow
+	for rows.Next() {
+		var i ListHikeRosterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Status,
+			&i.UserName,
+			&i.UserUsername,
+			&i.UserTgID,
+			&i.UserPhone,
+			&i.HikePriceGel,
+			&i.PaidAmount,
+			&i.PaymentStatus,
 		); err != nil {
 			return nil, err
 		}
@@ -256,32 +1008,47 @@ SELECT
     starts_at, 
     ends_at, 
     is_published, 
+    publish_at,
     created_at 
 FROM 
     hikes 
-ORDER BY is_published DESC, created_at DESC
-LIMIT $1 OFFSET $2
+WHERE CASE $1::text
+    WHEN 'published' THEN is_published AND ends_at >= $2::timestamptz
+    WHEN 'draft' THEN NOT is_published AND ends_at >= $2::timestamptz
+    WHEN 'past' THEN ends_at < $2::timestamptz
+    ELSE true
+END
+ORDER BY is_published DESC, created_at DESC, id DESC
+LIMIT $3 OFFSET $4
 `
 
 type ListHikesParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+	Filter string    `db:"filter" json:"filter"`
+	Now    time.Time `db:"now" json:"now"`
+	Limit  int32     `db:"limit" json:"limit"`
+	Offset int32     `db:"offset" json:"offset"`
 }
 
 type ListHikesRow struct {
-	ID            int32       `db:"id" json:"id"`
-	TitleRu       string      `db:"title_ru" json:"title_ru"`
-	DescriptionRu string      `db:"description_ru" json:"description_ru"`
-	TitleEn       pgtype.Text `db:"title_en" json:"title_en"`
-	DescriptionEn pgtype.Text `db:"description_en" json:"description_en"`
-	StartsAt      time.Time   `db:"starts_at" json:"starts_at"`
-	EndsAt        time.Time   `db:"ends_at" json:"ends_at"`
-	IsPublished   bool        `db:"is_published" json:"is_published"`
-	CreatedAt     time.Time   `db:"created_at" json:"created_at"`
+	ID            int32              `db:"id" json:"id"`
+	TitleRu       string             `db:"title_ru" json:"title_ru"`
+	DescriptionRu string             `db:"description_ru" json:"description_ru"`
+	TitleEn       pgtype.Text        `db:"title_en" json:"title_en"`
+	DescriptionEn pgtype.Text        `db:"description_en" json:"description_en"`
+	StartsAt      time.Time          `db:"starts_at" json:"starts_at"`
+	EndsAt        time.Time          `db:"ends_at" json:"ends_at"`
+	IsPublished   bool               `db:"is_published" json:"is_published"`
+	PublishAt     pgtype.Timestamptz `db:"publish_at" json:"publish_at"`
+	CreatedAt     time.Time          `db:"created_at" json:"created_at"`
 }
 
 func (q *Queries) ListHikes(ctx context.Context, arg ListHikesParams) ([]ListHikesRow, error) {
-	rows, err := q.db.Query(ctx, listHikes, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listHikes,
+		arg.Filter,
+		arg.Now,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -298,6 +1065,7 @@ func (q *Queries) ListHikes(ctx context.Context, arg ListHikesParams) ([]ListHik
 			&i.StartsAt,
 			&i.EndsAt,
 			&i.IsPublished,
+			&i.PublishAt,
 			&i.CreatedAt,
 		); err != nil {
 			return nil, err
@@ -310,9 +1078,113 @@ func (q *Queries) ListHikes(ctx context.Context, arg ListHikesParams) ([]ListHik
 	return items, nil
 }
 
+const markUntakenBookingsEscalated = `-- name: MarkUntakenBookingsEscalated :many
+UPDATE bookings
+SET escalated_at = now()
+WHERE status = 'new' AND escalated_at IS NULL AND updated_at < $1
+RETURNING id
+`
+
+func (q *Queries) MarkUntakenBookingsEscalated(ctx context.Context, updatedAt time.Time) ([]int32, error) {
+	rows, err := q.db.Query(ctx, markUntakenBookingsEscalated, updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const publishDueHikes = `-- name: PublishDueHikes :execrows
+UPDATE hikes
+SET is_published = true, publish_at = NULL, updated_at = now()
+WHERE is_published = false
+    AND publish_at <= $1::timestamptz
+    AND ends_at >= $1::timestamptz
+`
+
+func (q *Queries) PublishDueHikes(ctx context.Context, now time.Time) (int64, error) {
+	result, err := q.db.Exec(ctx, publishDueHikes, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reviewPayment = `-- name: ReviewPayment :one
+UPDATE payments
+SET status = $2, reviewed_by_admin_id = $3, reviewed_at = now()
+WHERE id = $1 AND status = 'submitted'
+RETURNING id
+`
+
+type ReviewPaymentParams struct {
+	ID                int32       `db:"id" json:"id"`
+	Status            string      `db:"status" json:"status"`
+	ReviewedByAdminID pgtype.Int4 `db:"reviewed_by_admin_id" json:"reviewed_by_admin_id"`
+}
+
+func (q *Queries) ReviewPayment(ctx context.Context, arg ReviewPaymentParams) (int32, error) {
+	row := q.db.QueryRow(ctx, reviewPayment, arg.ID, arg.Status, arg.ReviewedByAdminID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const saveFSMSession = `-- name: SaveFSMSession :exec
+INSERT INTO admin_fsm_sessions (tg_user_id, slot, state, data, updated_at)
+VALUES ($1, $2, $3, $4, now())
+ON CONFLICT (tg_user_id, slot)
+DO UPDATE SET
+    state      = EXCLUDED.state,
+    data       = EXCLUDED.data,
+    updated_at = EXCLUDED.updated_at
+`
+
+type SaveFSMSessionParams struct {
+	TgUserID int64  `db:"tg_user_id" json:"tg_user_id"`
+	Slot     string `db:"slot" json:"slot"`
+	State    string `db:"state" json:"state"`
+	Data     []byte `db:"data" json:"data"`
+}
+
+func (q *Queries) SaveFSMSession(ctx context.Context, arg SaveFSMSessionParams) error {
+	_, err := q.db.Exec(ctx, saveFSMSession,
+		arg.TgUserID,
+		arg.Slot,
+		arg.State,
+		arg.Data,
+	)
+	return err
+}
+
+const setHikeSeries = `-- name: SetHikeSeries :exec
+UPDATE hikes SET series_id = $2 WHERE id = $1
+`
+
+type SetHikeSeriesParams struct {
+	ID       int32       `db:"id" json:"id"`
+	SeriesID pgtype.Int4 `db:"series_id" json:"series_id"`
+}
+
+func (q *Queries) SetHikeSeries(ctx context.Context, arg SetHikeSeriesParams) error {
+	_, err := q.db.Exec(ctx, setHikeSeries, arg.ID, arg.SeriesID)
+	return err
+}
+
 const setPublished = `-- name: SetPublished :exec
 UPDATE hikes
-SET is_published = $1, updated_at = now()
+SET is_published = $1, publish_at = NULL, updated_at = now()
 WHERE id = $2
 `
 
@@ -328,18 +1200,19 @@ func (q *Queries) SetPublished(ctx context.Context, arg SetPublishedParams) erro
 
 const updateBookingStatus = `-- name: UpdateBookingStatus :one
 UPDATE bookings
-SET status = $2
-WHERE id = $1
-RETURNING id, hike_id, user_id, status, note, created_at, taken_by_admin_id, taken_at, updated_at
+SET status = $1, updated_at = now()
+WHERE id = $2 AND status = $3
+RETURNING id, hike_id, user_id, status, note, created_at, taken_by_admin_id, taken_at, updated_at, cancel_reason, admin_message_id, escalated_at
 `
 
 type UpdateBookingStatusParams struct {
-	ID        int32  `db:"id" json:"id"`
-	NewStatus string `db:"new_status" json:"new_status"`
+	NewStatus      string `db:"new_status" json:"new_status"`
+	ID             int32  `db:"id" json:"id"`
+	ExpectedStatus string `db:"expected_status" json:"expected_status"`
 }
 
 func (q *Queries) UpdateBookingStatus(ctx context.Context, arg UpdateBookingStatusParams) (Booking, error) {
-	row := q.db.QueryRow(ctx, updateBookingStatus, arg.ID, arg.NewStatus)
+	row := q.db.QueryRow(ctx, updateBookingStatus, arg.NewStatus, arg.ID, arg.ExpectedStatus)
 	var i Booking
 	err := row.Scan(
 		&i.ID,
@@ -351,6 +1224,9 @@ func (q *Queries) UpdateBookingStatus(ctx context.Context, arg UpdateBookingStat
 		&i.TakenByAdminID,
 		&i.TakenAt,
 		&i.UpdatedAt,
+		&i.CancelReason,
+		&i.AdminMessageID,
+		&i.EscalatedAt,
 	)
 	return i, err
 }
@@ -358,48 +1234,69 @@ func (q *Queries) UpdateBookingStatus(ctx context.Context, arg UpdateBookingStat
 const updateHike = `-- name: UpdateHike :one
 UPDATE hikes SET
     title_ru       = $2,
-    title_en       = $3,
-    description_ru = $4,
-    description_en = $5,
-    starts_at      = $6,
-    ends_at        = $7,
-    photo_file_id  = $8,
-    is_published   = $9,
-    distance_km    = $10,
-    elevation_gain_m = $11,
-    updated_at       = $12
-WHERE id = $1
-RETURNING id, title_ru, title_en, description_ru, description_en, starts_at, ends_at, photo_file_id, is_published, created_at, updated_at, image_path, price_gel, elevation_gain_m, distance_km, preview_ru
+    preview_ru     = $3,
+    title_en       = $4,
+    description_ru = $5,
+    description_en = $6,
+    starts_at      = $7,
+    ends_at        = $8,
+    photo_file_id  = $9,
+    price_gel      = $10,
+    distance_km    = $11,
+    elevation_gain_m = $12,
+    max_participants = $13,
+    publish_at       = $14,
+    unpublish_at     = $15,
+    preview_en       = $16,
+    difficulty       = $17,
+    tags             = $18,
+    updated_at       = now()
+WHERE id = $1 AND updated_at = $19
+RETURNING id, title_ru, title_en, description_ru, description_en, starts_at, ends_at, photo_file_id, is_published, created_at, updated_at, image_path, price_gel, elevation_gain_m, distance_km, preview_ru, max_participants, publish_at, unpublish_at, series_id, preview_en, difficulty, tags
 `
 
 type UpdateHikeParams struct {
-	ID             int32          `db:"id" json:"id"`
-	TitleRu        string         `db:"title_ru" json:"title_ru"`
-	TitleEn        pgtype.Text    `db:"title_en" json:"title_en"`
-	DescriptionRu  string         `db:"description_ru" json:"description_ru"`
-	DescriptionEn  pgtype.Text    `db:"description_en" json:"description_en"`
-	StartsAt       time.Time      `db:"starts_at" json:"starts_at"`
-	EndsAt         time.Time      `db:"ends_at" json:"ends_at"`
-	PhotoFileID    pgtype.Text    `db:"photo_file_id" json:"photo_file_id"`
-	IsPublished    bool           `db:"is_published" json:"is_published"`
-	DistanceKm     pgtype.Numeric `db:"distance_km" json:"distance_km"`
-	ElevationGainM pgtype.Int4    `db:"elevation_gain_m" json:"elevation_gain_m"`
-	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
+	ID              int32              `db:"id" json:"id"`
+	TitleRu         string             `db:"title_ru" json:"title_ru"`
+	PreviewRu       string             `db:"preview_ru" json:"preview_ru"`
+	TitleEn         pgtype.Text        `db:"title_en" json:"title_en"`
+	DescriptionRu   string             `db:"description_ru" json:"description_ru"`
+	DescriptionEn   pgtype.Text        `db:"description_en" json:"description_en"`
+	StartsAt        time.Time          `db:"starts_at" json:"starts_at"`
+	EndsAt          time.Time          `db:"ends_at" json:"ends_at"`
+	PhotoFileID     pgtype.Text        `db:"photo_file_id" json:"photo_file_id"`
+	PriceGel        int32              `db:"price_gel" json:"price_gel"`
+	DistanceKm      pgtype.Numeric     `db:"distance_km" json:"distance_km"`
+	ElevationGainM  pgtype.Int4        `db:"elevation_gain_m" json:"elevation_gain_m"`
+	MaxParticipants pgtype.Int4        `db:"max_participants" json:"max_participants"`
+	PublishAt       pgtype.Timestamptz `db:"publish_at" json:"publish_at"`
+	UnpublishAt     pgtype.Timestamptz `db:"unpublish_at" json:"unpublish_at"`
+	PreviewEn       pgtype.Text        `db:"preview_en" json:"preview_en"`
+	Difficulty      string             `db:"difficulty" json:"difficulty"`
+	Tags            []string           `db:"tags" json:"tags"`
+	UpdatedAt       time.Time          `db:"updated_at" json:"updated_at"`
 }
 
 func (q *Queries) UpdateHike(ctx context.Context, arg UpdateHikeParams) (Hike, error) {
 	row := q.db.QueryRow(ctx, updateHike,
 		arg.ID,
 		arg.TitleRu,
+		arg.PreviewRu,
 		arg.TitleEn,
 		arg.DescriptionRu,
 		arg.DescriptionEn,
 		arg.StartsAt,
 		arg.EndsAt,
 		arg.PhotoFileID,
-		arg.IsPublished,
+		arg.PriceGel,
 		arg.DistanceKm,
 		arg.ElevationGainM,
+		arg.MaxParticipants,
+		arg.PublishAt,
+		arg.UnpublishAt,
+		arg.PreviewEn,
+		arg.Difficulty,
+		arg.Tags,
 		arg.UpdatedAt,
 	)
 	var i Hike
@@ -420,6 +1317,13 @@ func (q *Queries) UpdateHike(ctx context.Context, arg UpdateHikeParams) (Hike, e
 		&i.ElevationGainM,
 		&i.DistanceKm,
 		&i.PreviewRu,
+		&i.MaxParticipants,
+		&i.PublishAt,
+		&i.UnpublishAt,
+		&i.SeriesID,
+		&i.PreviewEn,
+		&i.Difficulty,
+		&i.Tags,
 	)
 	return i, err
 }
@@ -445,8 +1349,7 @@ VALUES ($1, $2, $3, $4)
 ON CONFLICT (tg_user_id)
 DO UPDATE SET
     tg_username = EXCLUDED.tg_username,
-    full_name   = EXCLUDED.full_name,
-    lang        = EXCLUDED.lang
+    full_name   = EXCLUDED.full_name
 RETURNING id
 `
 
@@ -15,6 +15,20 @@ type Admin struct {
 	CreatedAt time.Time `db:"created_at" json:"created_at"`
 }
 
+type AdminFsmSession struct {
+	TgUserID  int64     `db:"tg_user_id" json:"tg_user_id"`
+	Slot      string    `db:"slot" json:"slot"`
+	State     string    `db:"state" json:"state"`
+	Data      []byte    `db:"data" json:"data"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+type BookingReminder struct {
+	BookingID int32     `db:"booking_id" json:"booking_id"`
+	Kind      string    `db:"kind" json:"kind"`
+	SentAt    time.Time `db:"sent_at" json:"sent_at"`
+}
+
 type Booking struct {
 	ID             int32              `db:"id" json:"id"`
 	HikeID         int32              `db:"hike_id" json:"hike_id"`
@@ -25,35 +39,63 @@ type Booking struct {
 	TakenByAdminID pgtype.Int4        `db:"taken_by_admin_id" json:"taken_by_admin_id"`
 	TakenAt        pgtype.Timestamptz `db:"taken_at" json:"taken_at"`
 	UpdatedAt      time.Time          `db:"updated_at" json:"updated_at"`
+	CancelReason   pgtype.Text        `db:"cancel_reason" json:"cancel_reason"`
+	AdminMessageID pgtype.Int8        `db:"admin_message_id" json:"admin_message_id"`
+	EscalatedAt    pgtype.Timestamptz `db:"escalated_at" json:"escalated_at"`
+}
+
+type HikeReview struct {
+	ID        int32       `db:"id" json:"id"`
+	HikeID    int32       `db:"hike_id" json:"hike_id"`
+	BookingID int32       `db:"booking_id" json:"booking_id"`
+	UserID    int32       `db:"user_id" json:"user_id"`
+	Rating    int32       `db:"rating" json:"rating"`
+	Comment   pgtype.Text `db:"comment" json:"comment"`
+	CreatedAt time.Time   `db:"created_at" json:"created_at"`
+	UpdatedAt time.Time   `db:"updated_at" json:"updated_at"`
+}
+
+type HikeSeries struct {
+	ID        int32     `db:"id" json:"id"`
+	CreatedAt time.Time `db:"created_at" json:"created_at"`
 }
 
 type Hike struct {
-	ID             int32          `db:"id" json:"id"`
-	TitleRu        string         `db:"title_ru" json:"title_ru"`
-	TitleEn        pgtype.Text    `db:"title_en" json:"title_en"`
-	DescriptionRu  string         `db:"description_ru" json:"description_ru"`
-	DescriptionEn  pgtype.Text    `db:"description_en" json:"description_en"`
-	StartsAt       time.Time      `db:"starts_at" json:"starts_at"`
-	EndsAt         time.Time      `db:"ends_at" json:"ends_at"`
-	PhotoFileID    pgtype.Text    `db:"photo_file_id" json:"photo_file_id"`
-	IsPublished    bool           `db:"is_published" json:"is_published"`
-	CreatedAt      time.Time      `db:"created_at" json:"created_at"`
-	UpdatedAt      time.Time      `db:"updated_at" json:"updated_at"`
-	ImagePath      pgtype.Text    `db:"image_path" json:"image_path"`
-	PriceGel       int32          `db:"price_gel" json:"price_gel"`
-	ElevationGainM pgtype.Int4    `db:"elevation_gain_m" json:"elevation_gain_m"`
-	DistanceKm     pgtype.Numeric `db:"distance_km" json:"distance_km"`
-	PreviewRu      string         `db:"preview_ru" json:"preview_ru"`
+	ID              int32              `db:"id" json:"id"`
+	TitleRu         string             `db:"title_ru" json:"title_ru"`
+	TitleEn         pgtype.Text        `db:"title_en" json:"title_en"`
+	DescriptionRu   string             `db:"description_ru" json:"description_ru"`
+	DescriptionEn   pgtype.Text        `db:"description_en" json:"description_en"`
+	StartsAt        time.Time          `db:"starts_at" json:"starts_at"`
+	EndsAt          time.Time          `db:"ends_at" json:"ends_at"`
+	PhotoFileID     pgtype.Text        `db:"photo_file_id" json:"photo_file_id"`
+	IsPublished     bool               `db:"is_published" json:"is_published"`
+	CreatedAt       time.Time          `db:"created_at" json:"created_at"`
+	UpdatedAt       time.Time          `db:"updated_at" json:"updated_at"`
+	ImagePath       pgtype.Text        `db:"image_path" json:"image_path"`
+	PriceGel        int32              `db:"price_gel" json:"price_gel"`
+	ElevationGainM  pgtype.Int4        `db:"elevation_gain_m" json:"elevation_gain_m"`
+	DistanceKm      pgtype.Numeric     `db:"distance_km" json:"distance_km"`
+	PreviewRu       string             `db:"preview_ru" json:"preview_ru"`
+	MaxParticipants pgtype.Int4        `db:"max_participants" json:"max_participants"`
+	PublishAt       pgtype.Timestamptz `db:"publish_at" json:"publish_at"`
+	UnpublishAt     pgtype.Timestamptz `db:"unpublish_at" json:"unpublish_at"`
+	SeriesID        pgtype.Int4        `db:"series_id" json:"series_id"`
+	PreviewEn       pgtype.Text        `db:"preview_en" json:"preview_en"`
+	Difficulty      string             `db:"difficulty" json:"difficulty"`
+	Tags            []string           `db:"tags" json:"tags"`
 }
 
 type Payment struct {
-	ID          int32          `db:"id" json:"id"`
-	BookingID   int32          `db:"booking_id" json:"booking_id"`
-	Amount      pgtype.Numeric `db:"amount" json:"amount"`
-	Currency    string         `db:"currency" json:"currency"`
-	ProofFileID pgtype.Text    `db:"proof_file_id" json:"proof_file_id"`
-	Status      string         `db:"status" json:"status"`
-	CreatedAt   time.Time      `db:"created_at" json:"created_at"`
+	ID                int32              `db:"id" json:"id"`
+	BookingID         int32              `db:"booking_id" json:"booking_id"`
+	Amount            pgtype.Numeric     `db:"amount" json:"amount"`
+	Currency          string             `db:"currency" json:"currency"`
+	ProofFileID       pgtype.Text        `db:"proof_file_id" json:"proof_file_id"`
+	Status            string             `db:"status" json:"status"`
+	CreatedAt         time.Time          `db:"created_at" json:"created_at"`
+	ReviewedByAdminID pgtype.Int4        `db:"reviewed_by_admin_id" json:"reviewed_by_admin_id"`
+	ReviewedAt        pgtype.Timestamptz `db:"reviewed_at" json:"reviewed_at"`
 }
 
 type TelegramUser struct {
@@ -64,4 +106,5 @@ type TelegramUser struct {
 	Lang       string      `db:"lang" json:"lang"`
 	IsAdmin    bool        `db:"is_admin" json:"is_admin"`
 	CreatedAt  time.Time   `db:"created_at" json:"created_at"`
+	Phone      pgtype.Text `db:"phone" json:"phone"`
 }
@@ -12,6 +12,77 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cancelBookingByClient = `-- name: CancelBookingByClient :one
+UPDATE bookings
+SET status = 'canceled', cancel_reason = $3, updated_at = now()
+WHERE id = $1 AND user_id = $2 AND status IN ('new', 'waitlisted', 'in_progress', 'confirmed')
+RETURNING hike_id
+`
+
+type CancelBookingByClientParams struct {
+	ID           int32       `db:"id" json:"id"`
+	UserID       int32       `db:"user_id" json:"user_id"`
+	CancelReason pgtype.Text `db:"cancel_reason" json:"cancel_reason"`
+}
+
+func (q *Queries) CancelBookingByClient(ctx context.Context, arg CancelBookingByClientParams) (int32, error) {
+	row := q.db.QueryRow(ctx, cancelBookingByClient, arg.ID, arg.UserID, arg.CancelReason)
+	var hike_id int32
+	err := row.Scan(&hike_id)
+	return hike_id, err
+}
+
+const countActiveBookings = `-- name: CountActiveBookings :one
+SELECT COUNT(*) FROM bookings
+WHERE hike_id = $1 AND status IN ('new', 'in_progress', 'confirmed')
+`
+
+func (q *Queries) CountActiveBookings(ctx context.Context, hikeID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveBookings, hikeID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countActualHikes = `-- name: CountActualHikes :one
+SELECT COUNT(*)
+FROM hikes
+WHERE is_published = true AND ends_at >= now()
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+    AND ($1::timestamptz IS NULL OR starts_at >= $1)
+    AND ($2::timestamptz IS NULL OR starts_at < $2)
+    AND ($3::int IS NULL OR price_gel <= $3)
+    AND ($4::text IS NULL OR difficulty = $4)
+    AND ($5::text IS NULL OR tags @> ARRAY[$5::text])
+    AND ($6::text IS NULL OR (
+        to_tsvector('russian', title_ru || ' ' || preview_ru || ' ' || description_ru)
+        || to_tsvector('english', COALESCE(title_en, '') || ' ' || COALESCE(preview_en, '') || ' ' || COALESCE(description_en, ''))
+    ) @@ (websearch_to_tsquery('russian', $6) || websearch_to_tsquery('english', $6)))
+`
+
+type CountActualHikesParams struct {
+	StartsFrom   pgtype.Timestamptz `db:"starts_from" json:"starts_from"`
+	StartsBefore pgtype.Timestamptz `db:"starts_before" json:"starts_before"`
+	MaxPrice     pgtype.Int4        `db:"max_price" json:"max_price"`
+	Difficulty   pgtype.Text        `db:"difficulty" json:"difficulty"`
+	Tag          pgtype.Text        `db:"tag" json:"tag"`
+	Query        pgtype.Text        `db:"query" json:"query"`
+}
+
+func (q *Queries) CountActualHikes(ctx context.Context, arg CountActualHikesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countActualHikes,
+		arg.StartsFrom,
+		arg.StartsBefore,
+		arg.MaxPrice,
+		arg.Difficulty,
+		arg.Tag,
+		arg.Query,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAdminIfNotExists = `-- name: CreateAdminIfNotExists :exec
 INSERT INTO admins (id)
 VALUES ($1)
@@ -26,7 +97,7 @@ func (q *Queries) CreateAdminIfNotExists(ctx context.Context, id int32) error {
 const createBooking = `-- name: CreateBooking :one
 INSERT INTO bookings (hike_id, user_id, status)
 VALUES ($1, $2, $3)
-ON CONFLICT (hike_id, user_id) DO NOTHING
+ON CONFLICT (hike_id, user_id) WHERE status IN ('new', 'waitlisted', 'in_progress', 'confirmed') DO NOTHING
 RETURNING id
 `
 
@@ -43,6 +114,31 @@ func (q *Queries) CreateBooking(ctx context.Context, arg CreateBookingParams) (i
 	return id, err
 }
 
+const createPayment = `-- name: CreatePayment :one
+INSERT INTO payments (booking_id, amount, currency, proof_file_id)
+VALUES ($1, $2, $3, $4)
+RETURNING id
+`
+
+type CreatePaymentParams struct {
+	BookingID   int32          `db:"booking_id" json:"booking_id"`
+	Amount      pgtype.Numeric `db:"amount" json:"amount"`
+	Currency    string         `db:"currency" json:"currency"`
+	ProofFileID pgtype.Text    `db:"proof_file_id" json:"proof_file_id"`
+}
+
+func (q *Queries) CreatePayment(ctx context.Context, arg CreatePaymentParams) (int32, error) {
+	row := q.db.QueryRow(ctx, createPayment,
+		arg.BookingID,
+		arg.Amount,
+		arg.Currency,
+		arg.ProofFileID,
+	)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getBookingByID = `-- name: GetBookingByID :one
 SELECT id, hike_id, user_id, status, taken_by_admin_id, taken_at
 FROM bookings WHERE id = $1
@@ -71,23 +167,108 @@ func (q *Queries) GetBookingByID(ctx context.Context, id int32) (GetBookingByIDR
 	return i, err
 }
 
+const getBookingDetails = `-- name: GetBookingDetails :one
+SELECT
+    b.id,
+    b.hike_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    h.ends_at AS hike_ends_at,
+    h.price_gel AS hike_price_gel,
+    b.user_id,
+    COALESCE(u.full_name, '') AS user_name,
+    COALESCE(u.tg_username, '') AS user_username,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang,
+    b.status,
+    b.taken_by_admin_id,
+    COALESCE(a.full_name, '') AS admin_name,
+    COALESCE(a.tg_username, '') AS admin_username,
+    a.tg_user_id AS admin_tg_id,
+    b.admin_message_id,
+    b.cancel_reason,
+    b.created_at
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN telegram_users a ON a.id = b.taken_by_admin_id
+WHERE b.id = $1
+`
+
+type GetBookingDetailsRow struct {
+	ID             int32       `db:"id" json:"id"`
+	HikeID         int32       `db:"hike_id" json:"hike_id"`
+	HikeTitle      string      `db:"hike_title" json:"hike_title"`
+	HikeTitleEn    string      `db:"hike_title_en" json:"hike_title_en"`
+	HikeStartsAt   time.Time   `db:"hike_starts_at" json:"hike_starts_at"`
+	HikeEndsAt     time.Time   `db:"hike_ends_at" json:"hike_ends_at"`
+	HikePriceGel   int32       `db:"hike_price_gel" json:"hike_price_gel"`
+	UserID         int32       `db:"user_id" json:"user_id"`
+	UserName       string      `db:"user_name" json:"user_name"`
+	UserUsername   string      `db:"user_username" json:"user_username"`
+	UserTgID       int64       `db:"user_tg_id" json:"user_tg_id"`
+	UserLang       string      `db:"user_lang" json:"user_lang"`
+	Status         string      `db:"status" json:"status"`
+	TakenByAdminID pgtype.Int4 `db:"taken_by_admin_id" json:"taken_by_admin_id"`
+	AdminName      string      `db:"admin_name" json:"admin_name"`
+	AdminUsername  string      `db:"admin_username" json:"admin_username"`
+	AdminTgID      pgtype.Int8 `db:"admin_tg_id" json:"admin_tg_id"`
+	AdminMessageID pgtype.Int8 `db:"admin_message_id" json:"admin_message_id"`
+	CancelReason   pgtype.Text `db:"cancel_reason" json:"cancel_reason"`
+	CreatedAt      time.Time   `db:"created_at" json:"created_at"`
+}
+
+func (q *Queries) GetBookingDetails(ctx context.Context, id int32) (GetBookingDetailsRow, error) {
+	row := q.db.QueryRow(ctx, getBookingDetails, id)
+	var i GetBookingDetailsRow
+	err := row.Scan(
+		&i.ID,
+		&i.HikeID,
+		&i.HikeTitle,
+		&i.HikeTitleEn,
+		&i.HikeStartsAt,
+		&i.HikeEndsAt,
+		&i.HikePriceGel,
+		&i.UserID,
+		&i.UserName,
+		&i.UserUsername,
+		&i.UserTgID,
+		&i.UserLang,
+		&i.Status,
+		&i.TakenByAdminID,
+		&i.AdminName,
+		&i.AdminUsername,
+		&i.AdminTgID,
+		&i.AdminMessageID,
+		&i.CancelReason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const getHike = `-- name: GetHike :one
 SELECT 
     id, 
     title_ru, 
     description_ru,
+    title_en,
+    description_en,
     starts_at, 
     ends_at
 FROM hikes
 WHERE id = $1 AND is_published = true
+    AND (unpublish_at IS NULL OR unpublish_at > now())
 `
 
 type GetHikeRow struct {
-	ID            int32     `db:"id" json:"id"`
-	TitleRu       string    `db:"title_ru" json:"title_ru"`
-	DescriptionRu string    `db:"description_ru" json:"description_ru"`
-	StartsAt      time.Time `db:"starts_at" json:"starts_at"`
-	EndsAt        time.Time `db:"ends_at" json:"ends_at"`
+	ID            int32       `db:"id" json:"id"`
+	TitleRu       string      `db:"title_ru" json:"title_ru"`
+	DescriptionRu string      `db:"description_ru" json:"description_ru"`
+	TitleEn       pgtype.Text `db:"title_en" json:"title_en"`
+	DescriptionEn pgtype.Text `db:"description_en" json:"description_en"`
+	StartsAt      time.Time   `db:"starts_at" json:"starts_at"`
+	EndsAt        time.Time   `db:"ends_at" json:"ends_at"`
 }
 
 func (q *Queries) GetHike(ctx context.Context, id int32) (GetHikeRow, error) {
@@ -97,12 +278,75 @@ func (q *Queries) GetHike(ctx context.Context, id int32) (GetHikeRow, error) {
 		&i.ID,
 		&i.TitleRu,
 		&i.DescriptionRu,
+		&i.TitleEn,
+		&i.DescriptionEn,
 		&i.StartsAt,
 		&i.EndsAt,
 	)
 	return i, err
 }
 
+const getHikeCapacityForUpdate = `-- name: GetHikeCapacityForUpdate :one
+SELECT max_participants FROM hikes
+WHERE id = $1 AND is_published
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+FOR UPDATE
+`
+
+func (q *Queries) GetHikeCapacityForUpdate(ctx context.Context, id int32) (pgtype.Int4, error) {
+	row := q.db.QueryRow(ctx, getHikeCapacityForUpdate, id)
+	var max_participants pgtype.Int4
+	err := row.Scan(&max_participants)
+	return max_participants, err
+}
+
+const getHikeRating = `-- name: GetHikeRating :one
+SELECT
+    COALESCE(AVG(r.rating), 0)::float8 AS average,
+    COUNT(*) AS reviews_count
+FROM hike_reviews r
+JOIN hikes h ON h.id = r.hike_id
+JOIN hikes t ON t.id = $1::int
+WHERE h.id = t.id OR h.series_id = t.series_id
+`
+
+type GetHikeRatingRow struct {
+	Average      float64 `db:"average" json:"average"`
+	ReviewsCount int64   `db:"reviews_count" json:"reviews_count"`
+}
+
+func (q *Queries) GetHikeRating(ctx context.Context, hikeID int32) (GetHikeRatingRow, error) {
+	row := q.db.QueryRow(ctx, getHikeRating, hikeID)
+	var i GetHikeRatingRow
+	err := row.Scan(&i.Average, &i.ReviewsCount)
+	return i, err
+}
+
+const getLatestUserHikeBooking = `-- name: GetLatestUserHikeBooking :one
+SELECT id, status
+FROM bookings
+WHERE hike_id = $1 AND user_id = $2
+ORDER BY id DESC
+LIMIT 1
+`
+
+type GetLatestUserHikeBookingParams struct {
+	HikeID int32 `db:"hike_id" json:"hike_id"`
+	UserID int32 `db:"user_id" json:"user_id"`
+}
+
+type GetLatestUserHikeBookingRow struct {
+	ID     int32  `db:"id" json:"id"`
+	Status string `db:"status" json:"status"`
+}
+
+func (q *Queries) GetLatestUserHikeBooking(ctx context.Context, arg GetLatestUserHikeBookingParams) (GetLatestUserHikeBookingRow, error) {
+	row := q.db.QueryRow(ctx, getLatestUserHikeBooking, arg.HikeID, arg.UserID)
+	var i GetLatestUserHikeBookingRow
+	err := row.Scan(&i.ID, &i.Status)
+	return i, err
+}
+
 const getTelegramUserByID = `-- name: GetTelegramUserByID :one
 SELECT id, tg_user_id, tg_username, full_name
 FROM telegram_users
@@ -128,42 +372,150 @@ func (q *Queries) GetTelegramUserByID(ctx context.Context, id int32) (GetTelegra
 	return i, err
 }
 
+const getTelegramUserLang = `-- name: GetTelegramUserLang :one
+SELECT lang FROM telegram_users WHERE tg_user_id = $1
+`
+
+func (q *Queries) GetTelegramUserLang(ctx context.Context, tgUserID int64) (string, error) {
+	row := q.db.QueryRow(ctx, getTelegramUserLang, tgUserID)
+	var lang string
+	err := row.Scan(&lang)
+	return lang, err
+}
+
+const getWaitlistPosition = `-- name: GetWaitlistPosition :one
+SELECT COUNT(*) FROM bookings
+WHERE hike_id = $1 AND status = 'waitlisted' AND id <= $2
+`
+
+type GetWaitlistPositionParams struct {
+	HikeID int32 `db:"hike_id" json:"hike_id"`
+	ID     int32 `db:"id" json:"id"`
+}
+
+func (q *Queries) GetWaitlistPosition(ctx context.Context, arg GetWaitlistPositionParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getWaitlistPosition, arg.HikeID, arg.ID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const listActualHikeStarts = `-- name: ListActualHikeStarts :many
+SELECT starts_at
+FROM hikes
+WHERE is_published = true AND ends_at >= now()
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+    AND ($1::int IS NULL OR price_gel <= $1)
+    AND ($2::text IS NULL OR difficulty = $2)
+    AND ($3::text IS NULL OR tags @> ARRAY[$3::text])
+    AND ($4::text IS NULL OR (
+        to_tsvector('russian', title_ru || ' ' || preview_ru || ' ' || description_ru)
+        || to_tsvector('english', COALESCE(title_en, '') || ' ' || COALESCE(preview_en, '') || ' ' || COALESCE(description_en, ''))
+    ) @@ (websearch_to_tsquery('russian', $4) || websearch_to_tsquery('english', $4)))
+ORDER BY starts_at ASC, id ASC
+`
+
+type ListActualHikeStartsParams struct {
+	MaxPrice   pgtype.Int4 `db:"max_price" json:"max_price"`
+	Difficulty pgtype.Text `db:"difficulty" json:"difficulty"`
+	Tag        pgtype.Text `db:"tag" json:"tag"`
+	Query      pgtype.Text `db:"query" json:"query"`
+}
+
+func (q *Queries) ListActualHikeStarts(ctx context.Context, arg ListActualHikeStartsParams) ([]time.Time, error) {
+	rows, err := q.db.Query(ctx, listActualHikeStarts,
+		arg.MaxPrice,
+		arg.Difficulty,
+		arg.Tag,
+		arg.Query,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []time.Time
+	for rows.Next() {
+		var starts_at time.Time
+		if err := rows.Scan(&starts_at); err != nil {
+			return nil, err
+		}
+		items = append(items, starts_at)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listActualHikes = `-- name: ListActualHikes :many
 SELECT 
     id, 
     title_ru, 
     preview_ru,
+    title_en,
+    preview_en,
     starts_at, 
     ends_at, 
     image_path,
     price_gel,
     distance_km,
-    elevation_gain_m
+    elevation_gain_m,
+    difficulty,
+    tags
 FROM hikes
 WHERE is_published = true AND ends_at >= now()
-ORDER BY starts_at ASC
-LIMIT $1 OFFSET $2
+    AND (unpublish_at IS NULL OR unpublish_at > now())
+    AND ($1::timestamptz IS NULL OR starts_at >= $1)
+    AND ($2::timestamptz IS NULL OR starts_at < $2)
+    AND ($3::int IS NULL OR price_gel <= $3)
+    AND ($4::text IS NULL OR difficulty = $4)
+    AND ($5::text IS NULL OR tags @> ARRAY[$5::text])
+    AND ($6::text IS NULL OR (
+        to_tsvector('russian', title_ru || ' ' || preview_ru || ' ' || description_ru)
+        || to_tsvector('english', COALESCE(title_en, '') || ' ' || COALESCE(preview_en, '') || ' ' || COALESCE(description_en, ''))
+    ) @@ (websearch_to_tsquery('russian', $6) || websearch_to_tsquery('english', $6)))
+ORDER BY starts_at ASC, id ASC
+LIMIT $7 OFFSET $8
 `
 
 type ListActualHikesParams struct {
-	Limit  int32 `db:"limit" json:"limit"`
-	Offset int32 `db:"offset" json:"offset"`
+	StartsFrom   pgtype.Timestamptz `db:"starts_from" json:"starts_from"`
+	StartsBefore pgtype.Timestamptz `db:"starts_before" json:"starts_before"`
+	MaxPrice     pgtype.Int4        `db:"max_price" json:"max_price"`
+	Difficulty   pgtype.Text        `db:"difficulty" json:"difficulty"`
+	Tag          pgtype.Text        `db:"tag" json:"tag"`
+	Query        pgtype.Text        `db:"query" json:"query"`
+	Limit        int32              `db:"limit" json:"limit"`
+	Offset       int32              `db:"offset" json:"offset"`
 }
 
 type ListActualHikesRow struct {
 	ID             int32          `db:"id" json:"id"`
 	TitleRu        string         `db:"title_ru" json:"title_ru"`
 	PreviewRu      string         `db:"preview_ru" json:"preview_ru"`
+	TitleEn        pgtype.Text    `db:"title_en" json:"title_en"`
+	PreviewEn      pgtype.Text    `db:"preview_en" json:"preview_en"`
 	StartsAt       time.Time      `db:"starts_at" json:"starts_at"`
 	EndsAt         time.Time      `db:"ends_at" json:"ends_at"`
 	ImagePath      pgtype.Text    `db:"image_path" json:"image_path"`
 	PriceGel       int32          `db:"price_gel" json:"price_gel"`
 	DistanceKm     pgtype.Numeric `db:"distance_km" json:"distance_km"`
 	ElevationGainM pgtype.Int4    `db:"elevation_gain_m" json:"elevation_gain_m"`
+	Difficulty     string         `db:"difficulty" json:"difficulty"`
+	Tags           []string       `db:"tags" json:"tags"`
 }
 
 func (q *Queries) ListActualHikes(ctx context.Context, arg ListActualHikesParams) ([]ListActualHikesRow, error) {
-	rows, err := q.db.Query(ctx, listActualHikes, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listActualHikes,
+		arg.StartsFrom,
+		arg.StartsBefore,
+		arg.MaxPrice,
+		arg.Difficulty,
+		arg.Tag,
+		arg.Query,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -175,12 +527,16 @@ func (q *Queries) ListActualHikes(ctx context.Context, arg ListActualHikesParams
 			&i.ID,
 			&i.TitleRu,
 			&i.PreviewRu,
+			&i.TitleEn,
+			&i.PreviewEn,
 			&i.StartsAt,
 			&i.EndsAt,
 			&i.ImagePath,
 			&i.PriceGel,
 			&i.DistanceKm,
 			&i.ElevationGainM,
+			&i.Difficulty,
+			&i.Tags,
 		); err != nil {
 			return nil, err
 		}
@@ -192,6 +548,295 @@ func (q *Queries) ListActualHikes(ctx context.Context, arg ListActualHikesParams
 	return items, nil
 }
 
+const listDueReminders = `-- name: ListDueReminders :many
+SELECT
+    b.id AS booking_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    u.tg_user_id AS user_tg_id,
+    u.lang AS user_lang,
+    COALESCE(a.full_name, '') AS admin_name,
+    COALESCE(a.tg_username, '') AS admin_username
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+JOIN telegram_users u ON u.id = b.user_id
+LEFT JOIN telegram_users a ON a.id = b.taken_by_admin_id
+WHERE
+    b.status = 'confirmed'
+    AND h.starts_at > $1
+    AND h.starts_at <= $2
+    AND NOT EXISTS (
+        SELECT 1 FROM booking_reminders r
+        WHERE r.booking_id = b.id AND r.kind = $3
+    )
+ORDER BY h.starts_at
+`
+
+type ListDueRemindersParams struct {
+	StartsAfter  time.Time `db:"starts_after" json:"starts_after"`
+	StartsBefore time.Time `db:"starts_before" json:"starts_before"`
+	Kind         string    `db:"kind" json:"kind"`
+}
+
+type ListDueRemindersRow struct {
+	BookingID     int32     `db:"booking_id" json:"booking_id"`
+	HikeTitle     string    `db:"hike_title" json:"hike_title"`
+	HikeTitleEn   string    `db:"hike_title_en" json:"hike_title_en"`
+	HikeStartsAt  time.Time `db:"hike_starts_at" json:"hike_starts_at"`
+	UserTgID      int64     `db:"user_tg_id" json:"user_tg_id"`
+	UserLang      string    `db:"user_lang" json:"user_lang"`
+	AdminName     string    `db:"admin_name" json:"admin_name"`
+	AdminUsername string    `db:"admin_username" json:"admin_username"`
+}
+
+func (q *Queries) ListDueReminders(ctx context.Context, arg ListDueRemindersParams) ([]ListDueRemindersRow, error) {
+	rows, err := q.db.Query(ctx, listDueReminders, arg.StartsAfter, arg.StartsBefore, arg.Kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDueRemindersRow
+	for rows.Next() {
+		var i ListDueRemindersRow
+		if err := rows.Scan(
+			&i.BookingID,
+			&i.HikeTitle,
+			&i.HikeTitleEn,
+			&i.HikeStartsAt,
+			&i.UserTgID,
+			&i.UserLang,
+			&i.AdminName,
+			&i.AdminUsername,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecentHikeReviews = `-- name: ListRecentHikeReviews :many
+SELECT
+    r.rating,
+    r.comment,
+    r.created_at,
+    COALESCE(u.full_name, '') AS user_name
+FROM hike_reviews r
+JOIN telegram_users u ON u.id = r.user_id
+JOIN hikes h ON h.id = r.hike_id
+JOIN hikes t ON t.id = $1::int
+WHERE (h.id = t.id OR h.series_id = t.series_id) AND r.comment IS NOT NULL
+ORDER BY r.created_at DESC
+LIMIT $2
+`
+
+type ListRecentHikeReviewsParams struct {
+	HikeID int32 `db:"hike_id" json:"hike_id"`
+	Limit  int32 `db:"limit" json:"limit"`
+}
+
+type ListRecentHikeReviewsRow struct {
+	Rating    int32       `db:"rating" json:"rating"`
+	Comment   pgtype.Text `db:"comment" json:"comment"`
+	CreatedAt time.Time   `db:"created_at" json:"created_at"`
+	UserName  string      `db:"user_name" json:"user_name"`
+}
+
+func (q *Queries) ListRecentHikeReviews(ctx context.Context, arg ListRecentHikeReviewsParams) ([]ListRecentHikeReviewsRow, error) {
+	rows, err := q.db.Query(ctx, listRecentHikeReviews, arg.HikeID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRecentHikeReviewsRow
+	for rows.Next() {
+		var i ListRecentHikeReviewsRow
+		if err := rows.Scan(
+			&i.Rating,
+			&i.Comment,
+			&i.CreatedAt,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserBookings = `-- name: ListUserBookings :many
+SELECT
+    b.id,
+    b.hike_id,
+    h.title_ru AS hike_title,
+    COALESCE(h.title_en, '') AS hike_title_en,
+    h.starts_at AS hike_starts_at,
+    h.ends_at AS hike_ends_at,
+    b.status,
+    b.created_at
+FROM bookings b
+JOIN hikes h ON h.id = b.hike_id
+WHERE b.user_id = $1
+ORDER BY h.starts_at ASC
+`
+
+type ListUserBookingsRow struct {
+	ID           int32     `db:"id" json:"id"`
+	HikeID       int32     `db:"hike_id" json:"hike_id"`
+	HikeTitle    string    `db:"hike_title" json:"hike_title"`
+	HikeTitleEn  string    `db:"hike_title_en" json:"hike_title_en"`
+	HikeStartsAt time.Time `db:"hike_starts_at" json:"hike_starts_at"`
+	HikeEndsAt   time.Time `db:"hike_ends_at" json:"hike_ends_at"`
+	Status       string    `db:"status" json:"status"`
+	CreatedAt    time.Time `db:"created_at" json:"created_at"`
+}
+
+func (q *Queries) ListUserBookings(ctx context.Context, userID int32) ([]ListUserBookingsRow, error) {
+	rows, err := q.db.Query(ctx, listUserBookings, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListUserBookingsRow
+	for rows.Next() {
+		var i ListUserBookingsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.HikeID,
+			&i.HikeTitle,
+			&i.HikeTitleEn,
+			&i.HikeStartsAt,
+			&i.HikeEndsAt,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markReminderSent = `-- name: MarkReminderSent :execrows
+INSERT INTO booking_reminders (booking_id, kind)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type MarkReminderSentParams struct {
+	BookingID int32  `db:"booking_id" json:"booking_id"`
+	Kind      string `db:"kind" json:"kind"`
+}
+
+func (q *Queries) MarkReminderSent(ctx context.Context, arg MarkReminderSentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markReminderSent, arg.BookingID, arg.Kind)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const promoteNextWaitlistedBooking = `-- name: PromoteNextWaitlistedBooking :one
+UPDATE bookings
+SET status = 'new', updated_at = now()
+WHERE id = (
+    SELECT w.id FROM bookings w
+    WHERE w.hike_id = $1 AND w.status = 'waitlisted'
+    ORDER BY w.id
+    LIMIT 1
+)
+RETURNING id
+`
+
+func (q *Queries) PromoteNextWaitlistedBooking(ctx context.Context, hikeID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, promoteNextWaitlistedBooking, hikeID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
+const setBookingAdminMessage = `-- name: SetBookingAdminMessage :exec
+UPDATE bookings SET admin_message_id = $2 WHERE id = $1
+`
+
+type SetBookingAdminMessageParams struct {
+	ID             int32       `db:"id" json:"id"`
+	AdminMessageID pgtype.Int8 `db:"admin_message_id" json:"admin_message_id"`
+}
+
+func (q *Queries) SetBookingAdminMessage(ctx context.Context, arg SetBookingAdminMessageParams) error {
+	_, err := q.db.Exec(ctx, setBookingAdminMessage, arg.ID, arg.AdminMessageID)
+	return err
+}
+
+const setHikeReviewComment = `-- name: SetHikeReviewComment :execrows
+UPDATE hike_reviews
+SET comment = $1, updated_at = now()
+WHERE booking_id = $2 AND user_id = $3
+`
+
+type SetHikeReviewCommentParams struct {
+	Comment   pgtype.Text `db:"comment" json:"comment"`
+	BookingID int32       `db:"booking_id" json:"booking_id"`
+	UserID    int32       `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) SetHikeReviewComment(ctx context.Context, arg SetHikeReviewCommentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setHikeReviewComment, arg.Comment, arg.BookingID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setTelegramUserLang = `-- name: SetTelegramUserLang :exec
+INSERT INTO telegram_users (tg_user_id, tg_username, full_name, lang)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (tg_user_id)
+DO UPDATE SET lang = EXCLUDED.lang
+`
+
+type SetTelegramUserLangParams struct {
+	TgUserID   int64       `db:"tg_user_id" json:"tg_user_id"`
+	TgUsername pgtype.Text `db:"tg_username" json:"tg_username"`
+	FullName   pgtype.Text `db:"full_name" json:"full_name"`
+	Lang       string      `db:"lang" json:"lang"`
+}
+
+func (q *Queries) SetTelegramUserLang(ctx context.Context, arg SetTelegramUserLangParams) error {
+	_, err := q.db.Exec(ctx, setTelegramUserLang,
+		arg.TgUserID,
+		arg.TgUsername,
+		arg.FullName,
+		arg.Lang,
+	)
+	return err
+}
+
+const setTelegramUserPhone = `-- name: SetTelegramUserPhone :exec
+UPDATE telegram_users SET phone = $2 WHERE tg_user_id = $1
+`
+
+type SetTelegramUserPhoneParams struct {
+	TgUserID int64       `db:"tg_user_id" json:"tg_user_id"`
+	Phone    pgtype.Text `db:"phone" json:"phone"`
+}
+
+func (q *Queries) SetTelegramUserPhone(ctx context.Context, arg SetTelegramUserPhoneParams) error {
+	_, err := q.db.Exec(ctx, setTelegramUserPhone, arg.TgUserID, arg.Phone)
+	return err
+}
+
 const takeBookingInProgress = `-- name: TakeBookingInProgress :one
 UPDATE bookings
 SET
@@ -242,14 +887,36 @@ func (q *Queries) UpdateBookingStatus(ctx context.Context, arg UpdateBookingStat
 	return id, err
 }
 
+const upsertHikeReview = `-- name: UpsertHikeReview :one
+INSERT INTO hike_reviews (hike_id, booking_id, user_id, rating)
+SELECT b.hike_id, b.id, b.user_id, $1::int
+FROM bookings b
+WHERE b.id = $2 AND b.user_id = $3 AND b.status = 'completed'
+ON CONFLICT (booking_id) DO UPDATE
+SET rating = EXCLUDED.rating, updated_at = now()
+RETURNING id
+`
+
+type UpsertHikeReviewParams struct {
+	Rating    int32 `db:"rating" json:"rating"`
+	BookingID int32 `db:"booking_id" json:"booking_id"`
+	UserID    int32 `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) UpsertHikeReview(ctx context.Context, arg UpsertHikeReviewParams) (int32, error) {
+	row := q.db.QueryRow(ctx, upsertHikeReview, arg.Rating, arg.BookingID, arg.UserID)
+	var id int32
+	err := row.Scan(&id)
+	return id, err
+}
+
 const upsertTelegramUser = `-- name: UpsertTelegramUser :one
 INSERT INTO telegram_users (tg_user_id, tg_username, full_name, lang)
 VALUES ($1, $2, $3, $4)
 ON CONFLICT (tg_user_id)
 DO UPDATE SET
     tg_username = EXCLUDED.tg_username,
-    full_name   = EXCLUDED.full_name,
-    lang        = EXCLUDED.lang
+    full_name   = EXCLUDED.full_name
 RETURNING id
 `
 